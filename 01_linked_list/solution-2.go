@@ -15,23 +15,37 @@ are the same.
 * list pointers. We do not have non nullable annotations in go - this sucks...
 */
 
-func listsSum(first, second * LinkedList) (*LinkedList, error) {
+func listsSum(first, second *LinkedList[int]) (*LinkedList[int], error) {
+	return listsCombine(first, second, func(a, b int) int { return a + b })
+}
+
+/*
+* Since the list became generic the sum itself is just one of the possible ways to combine two nodes. So the walk over both lists
+* moved into listsCombine which takes the combining function and listsSum only passes the integer addition. The complexity stays the same
+* O(n) with O(1) additional space, if we again do not count the result.
+*/
+
+func listsCombine[T comparable](first, second *LinkedList[T], combine func(a, b T) T) (*LinkedList[T], error) {
 	if first == nil || second == nil {
 		return nil, fmt.Errorf("one or both lists are nil")
 	}
 
+	if combine == nil {
+		return nil, fmt.Errorf("combine function is nil")
+	}
+
 	if first.Count() != second.Count() {
 		return nil, fmt.Errorf("not equal length lists")
 	}
 
 	firstPointer, secondPointer := first.head, second.head
-	result := &LinkedList{}
+	result := &LinkedList[T]{}
 
 	for firstPointer != nil && secondPointer != nil {
-		result.AddInTail(Node{value: firstPointer.value + secondPointer.value})
+		result.AddInTail(Node[T]{value: combine(firstPointer.value, secondPointer.value)})
 		firstPointer = firstPointer.next
 		secondPointer = secondPointer.next
 	}
 
 	return result, nil
-}
\ No newline at end of file
+}
@@ -1,21 +1,22 @@
 package linkedlist
 
 import (
+	"slices"
 	"testing"
 	"github.com/stretchr/testify/assert"
 )
 
 // helpers
 
-func makeList(values ...int) LinkedList {
-	list := LinkedList{}
+func makeList(values ...int) LinkedList[int] {
+	list := LinkedList[int]{}
 	for _, v := range values {
-		list.AddInTail(Node{value: v})
+		list.AddInTail(Node[int]{value: v})
 	}
 	return list
 }
 
-func toSlice(list *LinkedList) []int {
+func toSlice(list *LinkedList[int]) []int {
 	if list.head == nil {
 		return nil
 	}
@@ -30,7 +31,7 @@ func toSlice(list *LinkedList) []int {
 
 func Test_GivenEmptyList_WhenDeletingValue_ThenSizeRemainsZero(t *testing.T) {
 	// Given
-	list := LinkedList{}
+	list := LinkedList[int]{}
 
 	// When
 	list.Delete(42, false)
@@ -143,7 +144,7 @@ func Test_GivenMultiNodeList_WhenDeletingTailValue_ThenTailMovesBack(t *testing.
 
 func Test_GivenEmptyList_WhenDeletingAllValues_ThenListRemainsEmpty(t *testing.T) {
 	// Given
-	list := LinkedList{}
+	list := LinkedList[int]{}
 
 	// When
 	list.Delete(5, true)
@@ -234,7 +235,7 @@ func Test_GivenMultiNodeList_WhenDeletingAllNonexistentValues_ThenListUnchanged(
 
 func Test_GivenEmptyList_WhenCleanCalled_ThenListRemainsEmpty(t *testing.T) {
 	// Given
-	list := LinkedList{}
+	list := LinkedList[int]{}
 
 	// When
 	list.Clean()
@@ -277,7 +278,7 @@ func Test_GivenMultipleElementsList_WhenCleanCalled_ThenSubsequentAddWorksCorrec
 
 	// When
 	list.Clean()
-	list.AddInTail(Node{value: 99})
+	list.AddInTail(Node[int]{value: 99})
 
 	// Then
 	assert.Equal(t, 1, list.Count())
@@ -308,7 +309,7 @@ func Test_GivenMultipleElementsList_WhenCleanCalled_ThenFindAndDeleteReturnEmpty
 
 func Test_GivenEmptyList_WhenFindingValue_ThenErrorReturned(t *testing.T) {
 	// Given
-	list := LinkedList{}
+	list := LinkedList[int]{}
 
 	// When
 	_, err := list.Find(42)
@@ -386,7 +387,7 @@ func Test_GivenMultiNodeList_WhenFindingNonexistentValue_ThenErrorReturned(t *te
 
 func Test_GivenEmptyList_WhenFindingAllValues_ThenReturnsEmptySlice(t *testing.T) {
 	// Given
-	list := LinkedList{}
+	list := LinkedList[int]{}
 
 	// When
 	results := list.FindAll(42)
@@ -459,7 +460,7 @@ func Test_GivenMultiNodeList_WhenFindingAllNonMatchingValues_ThenReturnsEmptySli
 
 func Test_GivenEmptyList_WhenObtainingCount_ThenReturnsZero(t *testing.T) {
 	// Given
-	list := LinkedList{}
+	list := LinkedList[int]{}
 
 	// When/Then
 	assert.Equal(t, 0, list.Count())
@@ -495,7 +496,7 @@ func Test_GivenMiddleNode_WhenInsertingAfter_ThenNodeAppearsImmediatelyAfterIt(t
 	// Given
 	list := makeList(1, 2, 3, 4)
 	middle := list.head.next
-	newNode := Node{value: 99}
+	newNode := Node[int]{value: 99}
 
 	// When
 	list.Insert(middle, newNode)
@@ -513,7 +514,7 @@ func Test_GivenTailNode_WhenInsertingAfter_ThenNodeBecomesNewTail(t *testing.T)
 	// Given
 	list := makeList(10, 20, 30)
 	tail := list.tail
-	newNode := Node{value: 99}
+	newNode := Node[int]{value: 99}
 
 	// When
 	list.Insert(tail, newNode)
@@ -530,7 +531,7 @@ func Test_GivenHeadNode_WhenInsertingAfter_ThenNodeInsertedAsSecond(t *testing.T
 	// Given
 	list := makeList(5, 10, 15)
 	head := list.head
-	newNode := Node{value: 7}
+	newNode := Node[int]{value: 7}
 
 	// When
 	list.Insert(head, newNode)
@@ -546,7 +547,7 @@ func Test_GivenSingleNodeList_WhenInsertingAfterHead_ThenNodeAppended(t *testing
 	// Given
 	list := makeList(1)
 	head := list.head
-	newNode := Node{value: 2}
+	newNode := Node[int]{value: 2}
 
 	// When
 	list.Insert(head, newNode)
@@ -562,8 +563,8 @@ func Test_GivenSingleNodeList_WhenInsertingAfterHead_ThenNodeAppended(t *testing
 
 func Test_GivenEmptyList_WhenInsertingFirst_ThenNodeBecomesHeadAndTail(t *testing.T) {
 	// Given
-	list := LinkedList{}
-	newNode := Node{value: 10}
+	list := LinkedList[int]{}
+	newNode := Node[int]{value: 10}
 
 	// When
 	list.InsertFirst(newNode)
@@ -581,7 +582,7 @@ func Test_GivenEmptyList_WhenInsertingFirst_ThenNodeBecomesHeadAndTail(t *testin
 func Test_GivenNonEmptyList_WhenInsertingFirst_ThenNodePrependedToFront(t *testing.T) {
 	// Given
 	list := makeList(2, 3, 4)
-	newNode := Node{value: 1}
+	newNode := Node[int]{value: 1}
 
 	// When
 	list.InsertFirst(newNode)
@@ -595,12 +596,12 @@ func Test_GivenNonEmptyList_WhenInsertingFirst_ThenNodePrependedToFront(t *testi
 
 func Test_GivenMultipleInsertFirstCalls_WhenRepeatedlyAdding_ThenOrderReverses(t *testing.T) {
 	// Given
-	list := LinkedList{}
+	list := LinkedList[int]{}
 
 	// When
-	list.InsertFirst(Node{value: 3})
-	list.InsertFirst(Node{value: 2})
-	list.InsertFirst(Node{value: 1})
+	list.InsertFirst(Node[int]{value: 3})
+	list.InsertFirst(Node[int]{value: 2})
+	list.InsertFirst(Node[int]{value: 1})
 
 	// Then
 	assert.Equal(t, []int{1, 2, 3}, toSlice(&list))
@@ -613,8 +614,8 @@ func Test_GivenMultipleInsertFirstCalls_WhenRepeatedlyAdding_ThenOrderReverses(t
 
 func Test_GivenTwoEmptyLists_WhenSummed_ThenResultIsEmptyList(t *testing.T) {
 	// Given
-	listA := LinkedList{}
-	listB := LinkedList{}
+	listA := LinkedList[int]{}
+	listB := LinkedList[int]{}
 
 	// When
 	result, err := listsSum(&listA, &listB)
@@ -673,4 +674,161 @@ func Test_GivenListsOfDifferentLengths_WhenSummed_ThenErrorReturned(t *testing.T
 	// Then
 	assert.Nil(t, result)
 	assert.Error(t, err)
-}
\ No newline at end of file
+}
+
+// GENERIC VALUES
+
+type point struct {
+	x, y int
+}
+
+func Test_GivenStringList_WhenFindingExistingValue_ThenNodeReturned(t *testing.T) {
+	// Given
+	list := LinkedList[string]{}
+	list.AddInTail(Node[string]{value: "a"})
+	list.AddInTail(Node[string]{value: "b"})
+
+	// When
+	node, err := list.Find("b")
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "b", node.value)
+}
+
+func Test_GivenStringList_WhenFindingMissingValue_ThenZeroNodeAndErrorReturned(t *testing.T) {
+	// Given
+	list := LinkedList[string]{}
+	list.AddInTail(Node[string]{value: "a"})
+
+	// When
+	node, err := list.Find("z")
+
+	// Then
+	assert.Error(t, err)
+	assert.Equal(t, "", node.value)
+	assert.Nil(t, node.next)
+}
+
+func Test_GivenStructList_WhenDeletingAllEqualValues_ThenOnlyThoseRemoved(t *testing.T) {
+	// Given
+	list := LinkedList[point]{}
+	for _, p := range []point{{1, 2}, {3, 4}, {1, 2}, {5, 6}} {
+		list.AddInTail(Node[point]{value: p})
+	}
+
+	// When
+	list.Delete(point{1, 2}, true)
+
+	// Then
+	assert.Equal(t, 2, list.Count())
+	assert.Equal(t, point{3, 4}, list.head.value)
+	assert.Equal(t, point{5, 6}, list.tail.value)
+	assert.Len(t, list.FindAll(point{1, 2}), 0)
+}
+
+// LISTS COMBINE
+
+func Test_GivenTwoStringLists_WhenCombinedWithConcatenation_ThenElementwiseResultReturned(t *testing.T) {
+	// Given
+	listA, listB := LinkedList[string]{}, LinkedList[string]{}
+	for _, v := range []string{"a", "b", "c"} {
+		listA.AddInTail(Node[string]{value: v})
+	}
+	for _, v := range []string{"x", "y", "z"} {
+		listB.AddInTail(Node[string]{value: v})
+	}
+
+	// When
+	result, err := listsCombine(&listA, &listB, func(a, b string) string { return a + b })
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Count())
+	assert.Equal(t, "ax", result.head.value)
+	assert.Equal(t, "by", result.head.next.value)
+	assert.Equal(t, "cz", result.tail.value)
+	assert.Nil(t, result.tail.next)
+}
+
+func Test_GivenTwoIntLists_WhenCombinedWithMultiplication_ThenElementwiseProductReturned(t *testing.T) {
+	// Given
+	listA := makeList(1, 2, 3)
+	listB := makeList(4, 5, 6)
+
+	// When
+	result, err := listsCombine(&listA, &listB, func(a, b int) int { return a * b })
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 10, 18}, toSlice(result))
+}
+
+func Test_GivenNilCombineFunction_WhenCombined_ThenErrorReturned(t *testing.T) {
+	// Given
+	listA := makeList(1)
+	listB := makeList(2)
+
+	// When
+	result, err := listsCombine(&listA, &listB, nil)
+
+	// Then
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+func Test_GivenListsOfDifferentLengths_WhenCombined_ThenErrorReturned(t *testing.T) {
+	// Given
+	listA := makeList(1, 2)
+	listB := makeList(1)
+
+	// When
+	result, err := listsCombine(&listA, &listB, func(a, b int) int { return a - b })
+
+	// Then
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+// ALL
+
+func Test_GivenMultiNodeList_WhenRangingOverAll_ThenValuesFromHeadToTailAndListUnchanged(t *testing.T) {
+	// Given
+	list := makeList(1, 2, 3)
+
+	// When
+	values := slices.Collect(list.All())
+
+	// Then
+	assert.Equal(t, []int{1, 2, 3}, values)
+	assert.Equal(t, 3, list.Count())
+	assert.Equal(t, []int{1, 2, 3}, toSlice(&list))
+}
+
+func Test_GivenEmptyList_WhenRangingOverAll_ThenNothingYielded(t *testing.T) {
+	// Given
+	list := LinkedList[int]{}
+
+	// When
+	values := slices.Collect(list.All())
+
+	// Then
+	assert.Empty(t, values)
+}
+
+func Test_GivenMultiNodeList_WhenBreakingOutOfAll_ThenIterationStops(t *testing.T) {
+	// Given
+	list := makeList(1, 2, 3, 4)
+	var visited []int
+
+	// When
+	for v := range list.All() {
+		visited = append(visited, v)
+		if v == 2 {
+			break
+		}
+	}
+
+	// Then
+	assert.Equal(t, []int{1, 2}, visited)
+}
@@ -2,20 +2,21 @@ package linkedlist
 
 import (
 	"fmt"
+	"iter"
 )
 
-type Node struct {
-	next  *Node
-	value int
+type Node[T comparable] struct {
+	next  *Node[T]
+	value T
 }
 
-type LinkedList struct {
-	head  *Node
-	tail  *Node
+type LinkedList[T comparable] struct {
+	head  *Node[T]
+	tail  *Node[T]
 	count int
 }
 
-func (l *LinkedList) AddInTail(item Node) {
+func (l *LinkedList[T]) AddInTail(item Node[T]) {
 	if l.head == nil {
 		l.head = &item
 	} else {
@@ -26,23 +27,23 @@ func (l *LinkedList) AddInTail(item Node) {
 	l.tail = &item
 }
 
-func (l *LinkedList) Count() int {
+func (l *LinkedList[T]) Count() int {
 	return l.count
 }
 
 // error не nil, если узел не найден
-func (l *LinkedList) Find(n int) (Node, error) {
+func (l *LinkedList[T]) Find(n T) (Node[T], error) {
 	for temp := l.head; temp != nil; temp = temp.next {
 		if temp.value == n {
 			return *temp, nil
 		}
 	}
 
-	return Node{value: -1, next: nil}, fmt.Errorf("node with value %d not found", n)
+	return Node[T]{}, fmt.Errorf("node with value %v not found", n)
 }
 
-func (l *LinkedList) FindAll(n int) []Node {
-	var nodes []Node
+func (l *LinkedList[T]) FindAll(n T) []Node[T] {
+	var nodes []Node[T]
 
 	for temp := l.head; temp != nil; temp = temp.next {
 		if temp.value == n {
@@ -53,7 +54,7 @@ func (l *LinkedList) FindAll(n int) []Node {
 	return nodes
 }
 
-func (l *LinkedList) Delete(n int, all bool) {
+func (l *LinkedList[T]) Delete(n T, all bool) {
 	l.head = l.DeleteRec(l.head, n, 0, all)
 
 	if l.head == nil {
@@ -61,7 +62,7 @@ func (l *LinkedList) Delete(n int, all bool) {
 	}
 }
 
-func (l *LinkedList) DeleteRec(temp *Node, n, delCount int, all bool) *Node {
+func (l *LinkedList[T]) DeleteRec(temp *Node[T], n T, delCount int, all bool) *Node[T] {
 	if temp == nil {
 		return nil
 	}
@@ -86,7 +87,7 @@ func (l *LinkedList) DeleteRec(temp *Node, n, delCount int, all bool) *Node {
 	return temp
 }
 
-func (l *LinkedList) Insert(after *Node, add Node) {
+func (l *LinkedList[T]) Insert(after *Node[T], add Node[T]) {
 	if after == nil {
         l.InsertFirst(add)
         return
@@ -99,7 +100,7 @@ func (l *LinkedList) Insert(after *Node, add Node) {
 	}
 }
 
-func (l *LinkedList) InsertFirst(first Node) {
+func (l *LinkedList[T]) InsertFirst(first Node[T]) {
 	if l.head == nil {
         l.head = &first
         l.tail = &first
@@ -110,8 +111,19 @@ func (l *LinkedList) InsertFirst(first Node) {
     l.count++
 }
 
-func (l *LinkedList) Clean() {
+func (l *LinkedList[T]) Clean() {
 	l.head = nil
 	l.tail = nil
 	l.count = 0
 }
+
+// values from head to tail, the list itself is not changed
+func (l *LinkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for temp := l.head; temp != nil; temp = temp.next {
+			if !yield(temp.value) {
+				return
+			}
+		}
+	}
+}
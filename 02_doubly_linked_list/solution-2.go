@@ -1,5 +1,7 @@
 package doubly_linked_list
 
+import "errors"
+
 /*
 * 1. Linked lists - task number 9 - reverse linked list
 *
@@ -8,26 +10,32 @@ package doubly_linked_list
 * Solved this task already some time ago on leet code, but wanted to do now using recursion, all solution with recusion are damn elegant. In essence we just need
 * to pass the prev pointer to the recursive function and then reorganize them. The time complexity is still O(n). Space complexity is again arguable - if we count
 * stack allocations then O(n) but without any loops this looks more elegant :)
+*
+* Since the list has Backward the prev pointers can not be ignored anymore - every node swaps its next and prev, the old next
+* becomes the new prev. Otherwise the list only works from the head and Backward stops after the first node.
 */
 
-func (list *LinkedList2) Reverse() {
+func (list *LinkedList2[T]) Reverse() {
 	if list.head == nil || list.head == list.tail {
 		return
 	}
 
+	list.version++
 	next := list.head.next
 	list.tail = list.head
 	list.tail.next = nil
+	list.tail.prev = next
 	list.head = Reverse(list.head, next)
 }
 
-func Reverse(prev, head * Node) *Node {
+func Reverse[T comparable](prev, head *Node[T]) *Node[T] {
 	if head == nil {
 		return prev
 	}
 
 	next := head.next
 	head.next = prev
+	head.prev = next
 	prev = head
 	return Reverse(prev, next)
 }
@@ -39,7 +47,7 @@ func Reverse(prev, head * Node) *Node {
 * turtle hare algorithm where we keep slow and fast pointers and when they meet then there is a cycle. Like in real life :) The complexity is still O(n).
 */
 
-func (list *LinkedList2) IsCyclic() bool {
+func (list *LinkedList2[T]) IsCyclic() bool {
 	slow, fast := list.head, list.head
 	for fast != nil && fast.next != nil {
 		slow = slow.next
@@ -60,23 +68,41 @@ func (list *LinkedList2) IsCyclic() bool {
 * linked list with 2 elements. In this case the doubly linked list would be great :) So we keep splitting the list until there are either no elements or just 1 and then merge them together
 * into a sorted list. Also recursively! I liked this idea that we just take from both lists depending on their heads value the correct head and then just set the `head.next` in another recursive
 * call to this function. Amazing... The complexity of this solution is O(n * log(n))
+*
+* After making the list generic the `<=` is gone and the caller passes the comparator in the same form as slices.SortFunc
+* expects it: negative when a < b, zero when equal and positive otherwise. The sort is stable - on equal values mergeLists always
+* takes the node from the left half first, so records with the same key keep the order they had before. While searching for the
+* new tail we also restore the prev pointers which the merge ignores, so the list is a proper doubly linked list after sorting.
 */
-func (list *LinkedList2) Sort() {
-	list.head = SortRec(list.head)
 
+// stable merge sort by cmp - values which cmp treats as equal keep their relative order. A nil cmp is rejected with an error
+// and the list stays as it was
+func (list *LinkedList2[T]) Sort(cmp func(a, b T) int) error {
+	if cmp == nil {
+		return errors.New("nil comparator")
+	}
+
+	list.version++
+	list.head = SortRec(list.head, cmp)
+
+	var prev *Node[T]
 	temp := list.head
 
-	for ; temp != nil && temp.next != nil; temp = temp.next {}
+	for ; temp != nil; temp = temp.next {
+		temp.prev = prev
+		prev = temp
+	}
 
-	list.tail = temp
+	list.tail = prev
+	return nil
 }
 
-func SortRec(head *Node) *Node {
+func SortRec[T comparable](head *Node[T], cmp func(a, b T) int) *Node[T] {
 	if head == nil || head.next == nil {
 		return head
 	}
 
-	var slow, fast, temp *Node = head, head, nil
+	var slow, fast, temp *Node[T] = head, head, nil
 
 	for fast != nil && fast.next != nil {
 		temp = slow
@@ -86,13 +112,13 @@ func SortRec(head *Node) *Node {
 
 	temp.next = nil
 
-	left := SortRec(head)
-	right := SortRec(slow)
+	left := SortRec(head, cmp)
+	right := SortRec(slow, cmp)
 
-	return mergeLists(left, right)
+	return mergeLists(left, right, cmp)
 }
 
-func mergeLists(list1, list2 *Node) *Node {
+func mergeLists[T comparable](list1, list2 *Node[T], cmp func(a, b T) int) *Node[T] {
 	if list1 == nil {
 		return list2
 	}
@@ -101,12 +127,12 @@ func mergeLists(list1, list2 *Node) *Node {
 		return list1
 	}
 
-	if list1.value <= list2.value {
-		list1.next = mergeLists(list1.next, list2)
+	if cmp(list1.value, list2.value) <= 0 {
+		list1.next = mergeLists(list1.next, list2, cmp)
 		return list1
 	}
 
-	list2.next = mergeLists(list1, list2.next)
+	list2.next = mergeLists(list1, list2.next, cmp)
 	return list2
 }
 
@@ -118,31 +144,33 @@ func mergeLists(list1, list2 *Node) *Node {
 * and for this reason have to alway call init to make sure the invariant for this technique holds. But the rest is pretty straightforware and we have way less if checks.
 */
 
-func (l *LinkedList2) init() {
+func (l *LinkedList2[T]) init() {
 	if l.head != nil { return }
-	l.head, l.tail = &Node{}, &Node{}
+	l.head, l.tail = &Node[T]{}, &Node[T]{}
 	l.head.next, l.tail.prev = l.tail, l.head
 }
 
-func (l *LinkedList2) AddInTail2(item Node) {
+func (l *LinkedList2[T]) AddInTail2(item Node[T]) {
 	l.init()
 	p := l.tail.prev
 	item.prev, item.next = p, l.tail
 	p.next = &item
 	l.tail.prev = &item
 	l.count++
+	l.version++
 }
 
-func (l *LinkedList2) InsertFirst2(first Node) {
+func (l *LinkedList2[T]) InsertFirst2(first Node[T]) {
 	l.init()
 	n := l.head.next
 	first.prev, first.next = l.head, n
 	l.head.next = &first
 	n.prev = &first
 	l.count++
+	l.version++
 }
 
-func (l *LinkedList2) Insert2(after *Node, add Node) {
+func (l *LinkedList2[T]) Insert2(after *Node[T], add Node[T]) {
 	l.init()
 	if after == nil {
 		n := l.head.next
@@ -150,6 +178,7 @@ func (l *LinkedList2) Insert2(after *Node, add Node) {
 		l.head.next = &add
 		n.prev = &add
 		l.count++
+		l.version++
 		return
 	}
 	// assume 'after' is in the list per spec
@@ -158,9 +187,10 @@ func (l *LinkedList2) Insert2(after *Node, add Node) {
 	after.next = &add
 	n.prev = &add
 	l.count++
+	l.version++
 }
 
-func (l *LinkedList2) Delete2(val int, all bool) {
+func (l *LinkedList2[T]) Delete2(val T, all bool) {
 	l.init()
 	for n := l.head.next; n != l.tail; {
 		if n.value == val {
@@ -170,6 +200,7 @@ func (l *LinkedList2) Delete2(val int, all bool) {
 			n.next.prev = n.prev
 			n.prev, n.next = nil, nil
 			l.count--
+			l.version++
 			if !all { return }
 			n = next
 			continue
@@ -1,6 +1,9 @@
 package doubly_linked_list
 
 import (
+	"cmp"
+	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -10,15 +13,15 @@ import (
 // helpers
 // ------------------------
 
-func makeList2(values ...int) LinkedList2 {
-	var list LinkedList2
+func makeList2(values ...int) LinkedList2[int] {
+	var list LinkedList2[int]
 	for _, v := range values {
-		list.AddInTail(Node{value: v})
+		list.AddInTail(Node[int]{value: v})
 	}
 	return list
 }
 
-func toSlice(list *LinkedList2) []int {
+func toSlice(list *LinkedList2[int]) []int {
 	res := make([]int, 0, list.Count())
 	for n := list.head; n != nil; n = n.next {
 		res = append(res, n.value)
@@ -26,7 +29,7 @@ func toSlice(list *LinkedList2) []int {
 	return res
 }
 
-func toReverseSlice(list *LinkedList2) []int {
+func toReverseSlice(list *LinkedList2[int]) []int {
 	res := make([]int, 0, list.Count())
 	for n := list.tail; n != nil; n = n.prev {
 		res = append(res, n.value)
@@ -45,7 +48,7 @@ func reverseInts(in []int) []int {
 // DELETE (single)
 
 func Test_GivenEmptyList_WhenDeletingValue_ThenSizeRemainsZero_Doubly(t *testing.T) {
-	list := LinkedList2{}
+	list := LinkedList2[int]{}
 
 	list.Delete(42, false)
 
@@ -155,7 +158,7 @@ func Test_GivenMultiNodeList_WhenDeletingTailValue_ThenTailMovesBack_Doubly(t *t
 // DELETE ALL
 
 func Test_GivenEmptyList_WhenDeletingAllValues_ThenListRemainsEmpty_Doubly(t *testing.T) {
-	list := LinkedList2{}
+	list := LinkedList2[int]{}
 
 	list.Delete(5, true)
 
@@ -242,7 +245,7 @@ func Test_GivenMultiNodeList_WhenDeletingAllNonexistentValues_ThenListUnchanged_
 // CLEAN
 
 func Test_GivenEmptyList_WhenCleanCalled_ThenListRemainsEmpty_Doubly(t *testing.T) {
-	list := LinkedList2{}
+	list := LinkedList2[int]{}
 
 	list.Clean()
 
@@ -281,7 +284,7 @@ func Test_GivenMultipleElementsList_WhenCleanCalled_ThenSubsequentAddWorksCorrec
 	list := makeList2(10, 20, 30)
 
 	list.Clean()
-	list.AddInTail(Node{value: 99})
+	list.AddInTail(Node[int]{value: 99})
 
 	assert.Equal(t, 1, list.Count())
 	assert.Equal(t, []int{99}, toSlice(&list))
@@ -296,7 +299,7 @@ func Test_GivenMultipleElementsList_WhenCleanCalled_ThenSubsequentAddWorksCorrec
 // FIND
 
 func Test_GivenEmptyList_WhenFindingValue_ThenErrorReturned_Doubly(t *testing.T) {
-	list := LinkedList2{}
+	list := LinkedList2[int]{}
 
 	_, err := list.Find(42)
 
@@ -364,7 +367,7 @@ func Test_GivenMultiNodeList_WhenFindingNonexistentValue_ThenErrorReturned_Doubl
 // FIND ALL
 
 func Test_GivenEmptyList_WhenFindingAllValues_ThenReturnsEmptySlice_Doubly(t *testing.T) {
-	list := LinkedList2{}
+	list := LinkedList2[int]{}
 
 	results := list.FindAll(42)
 
@@ -430,7 +433,7 @@ func Test_GivenMultiNodeList_WhenFindingAllNonMatchingValues_ThenReturnsEmptySli
 // COUNT
 
 func Test_GivenEmptyList_WhenObtainingCount_ThenReturnsZero_Doubly(t *testing.T) {
-	list := LinkedList2{}
+	list := LinkedList2[int]{}
 
 	assert.Equal(t, 0, list.Count())
 	assert.Nil(t, list.head)
@@ -465,7 +468,7 @@ func Test_GivenMultipleElementsList_WhenObtainingCount_ThenReturnsCorrectValue_D
 func Test_GivenMiddleNode_WhenInsertingAfter_ThenNodeAppearsImmediatelyAfterIt_Doubly(t *testing.T) {
 	list := makeList2(1, 2, 3, 4)
 	middle := list.head.next
-	newNode := Node{value: 99}
+	newNode := Node[int]{value: 99}
 
 	list.Insert(middle, newNode)
 
@@ -485,7 +488,7 @@ func Test_GivenMiddleNode_WhenInsertingAfter_ThenNodeAppearsImmediatelyAfterIt_D
 func Test_GivenTailNode_WhenInsertingAfter_ThenNodeBecomesNewTail_Doubly(t *testing.T) {
 	list := makeList2(10, 20, 30)
 	tail := list.tail
-	newNode := Node{value: 99}
+	newNode := Node[int]{value: 99}
 
 	list.Insert(tail, newNode)
 
@@ -502,7 +505,7 @@ func Test_GivenTailNode_WhenInsertingAfter_ThenNodeBecomesNewTail_Doubly(t *test
 func Test_GivenHeadNode_WhenInsertingAfter_ThenNodeInsertedAsSecond_Doubly(t *testing.T) {
 	list := makeList2(5, 10, 15)
 	head := list.head
-	newNode := Node{value: 7}
+	newNode := Node[int]{value: 7}
 
 	list.Insert(head, newNode)
 
@@ -520,7 +523,7 @@ func Test_GivenHeadNode_WhenInsertingAfter_ThenNodeInsertedAsSecond_Doubly(t *te
 func Test_GivenSingleNodeList_WhenInsertingAfterHead_ThenNodeAppended_Doubly(t *testing.T) {
 	list := makeList2(1)
 	head := list.head
-	newNode := Node{value: 2}
+	newNode := Node[int]{value: 2}
 
 	list.Insert(head, newNode)
 
@@ -537,8 +540,8 @@ func Test_GivenSingleNodeList_WhenInsertingAfterHead_ThenNodeAppended_Doubly(t *
 // INSERT FIRST
 
 func Test_GivenEmptyList_WhenInsertingFirst_ThenNodeBecomesHeadAndTail_Doubly(t *testing.T) {
-	list := LinkedList2{}
-	newNode := Node{value: 10}
+	list := LinkedList2[int]{}
+	newNode := Node[int]{value: 10}
 
 	list.InsertFirst(newNode)
 
@@ -553,7 +556,7 @@ func Test_GivenEmptyList_WhenInsertingFirst_ThenNodeBecomesHeadAndTail_Doubly(t
 
 func Test_GivenNonEmptyList_WhenInsertingFirst_ThenNodePrependedToFront_Doubly(t *testing.T) {
 	list := makeList2(2, 3, 4)
-	newNode := Node{value: 1}
+	newNode := Node[int]{value: 1}
 
 	list.InsertFirst(newNode)
 
@@ -568,11 +571,11 @@ func Test_GivenNonEmptyList_WhenInsertingFirst_ThenNodePrependedToFront_Doubly(t
 }
 
 func Test_GivenMultipleInsertFirstCalls_WhenRepeatedlyAdding_ThenOrderReverses_Doubly(t *testing.T) {
-	list := LinkedList2{}
+	list := LinkedList2[int]{}
 
-	list.InsertFirst(Node{value: 3})
-	list.InsertFirst(Node{value: 2})
-	list.InsertFirst(Node{value: 1})
+	list.InsertFirst(Node[int]{value: 3})
+	list.InsertFirst(Node[int]{value: 2})
+	list.InsertFirst(Node[int]{value: 1})
 
 	fw := toSlice(&list)
 	assert.Equal(t, []int{1, 2, 3}, fw)
@@ -595,9 +598,9 @@ func isNonDecreasing(a []int) bool {
 }
 
 func Test_GivenEmptyList_WhenSortCalled_ThenListRemainsEmpty(t *testing.T) {
-	list := LinkedList2{}
+	list := LinkedList2[int]{}
 
-	list.Sort()
+	list.Sort(cmp.Compare[int])
 
 	assert.Equal(t, 0, list.Count())
 	assert.Equal(t, []int{}, toSlice(&list))
@@ -609,7 +612,7 @@ func Test_GivenSingleElementList_WhenSortCalled_ThenListUnchanged(t *testing.T)
 	list := makeList2(7)
 
 	beforeCount := list.Count()
-	list.Sort()
+	list.Sort(cmp.Compare[int])
 
 	assert.Equal(t, beforeCount, list.Count())
 	assert.Equal(t, []int{7}, toSlice(&list))
@@ -624,7 +627,7 @@ func Test_GivenAlreadySortedList_WhenSortCalled_ThenListUnchanged(t *testing.T)
 	before := toSlice(&list)
 	beforeCount := list.Count()
 
-	list.Sort()
+	list.Sort(cmp.Compare[int])
 
 	after := toSlice(&list)
 	assert.Equal(t, beforeCount, list.Count())
@@ -635,7 +638,7 @@ func Test_GivenAlreadySortedList_WhenSortCalled_ThenListUnchanged(t *testing.T)
 func Test_GivenReverseSortedList_WhenSortCalled_ThenBecomesAscending(t *testing.T) {
 	list := makeList2(5, 4, 3, 2, 1)
 
-	list.Sort()
+	list.Sort(cmp.Compare[int])
 
 	fw := toSlice(&list)
 	assert.Equal(t, []int{1, 2, 3, 4, 5}, fw)
@@ -646,7 +649,7 @@ func Test_GivenReverseSortedList_WhenSortCalled_ThenBecomesAscending(t *testing.
 func Test_GivenListWithDuplicates_WhenSortCalled_ThenIsNonDecreasing(t *testing.T) {
 	list := makeList2(4, 2, 3, 2, 4, 1, 1, 3)
 
-	list.Sort()
+	list.Sort(cmp.Compare[int])
 
 	fw := toSlice(&list)
 	assert.True(t, isNonDecreasing(fw))
@@ -657,7 +660,7 @@ func Test_GivenListWithDuplicates_WhenSortCalled_ThenIsNonDecreasing(t *testing.
 func Test_GivenListWithNegatives_WhenSortCalled_ThenAscendingAcrossSign(t *testing.T) {
 	list := makeList2(0, -1, 5, -3, 2, -2)
 
-	list.Sort()
+	list.Sort(cmp.Compare[int])
 
 	fw := toSlice(&list)
 	assert.True(t, isNonDecreasing(fw))
@@ -668,7 +671,7 @@ func Test_GivenListWithNegatives_WhenSortCalled_ThenAscendingAcrossSign(t *testi
 func Test_GivenTwoElementsSwapped_WhenSortCalled_ThenFixed(t *testing.T) {
 	list := makeList2(2, 1)
 
-	list.Sort()
+	list.Sort(cmp.Compare[int])
 
 	fw := toSlice(&list)
 	assert.Equal(t, []int{1, 2}, fw)
@@ -678,10 +681,10 @@ func Test_GivenTwoElementsSwapped_WhenSortCalled_ThenFixed(t *testing.T) {
 func Test_GivenAlternatingValues_WhenSortCalled_Twice_ThenIdempotent(t *testing.T) {
 	list := makeList2(3, 1, 4, 1, 5, 9, 2, 6)
 
-	list.Sort()
+	list.Sort(cmp.Compare[int])
 	once := toSlice(&list)
 
-	list.Sort()
+	list.Sort(cmp.Compare[int])
 	twice := toSlice(&list)
 
 	assert.Equal(t, once, twice, "Sort should be idempotent")
@@ -692,7 +695,7 @@ func Test_GivenAlternatingValues_WhenSortCalled_Twice_ThenIdempotent(t *testing.
 func Test_GivenAllEqualValues_WhenSortCalled_ThenStructureConsistent(t *testing.T) {
 	list := makeList2(7, 7, 7, 7)
 
-	list.Sort()
+	list.Sort(cmp.Compare[int])
 
 	fw := toSlice(&list)
 	assert.Equal(t, []int{7, 7, 7, 7}, fw)
@@ -702,7 +705,7 @@ func Test_GivenAllEqualValues_WhenSortCalled_ThenStructureConsistent(t *testing.
 // REVERSE
 
 func Test_GivenEmptyList_WhenReverseCalled_ThenListRemainsEmpty(t *testing.T) {
-	list := LinkedList2{}
+	list := LinkedList2[int]{}
 
 	list.Reverse()
 
@@ -804,4 +807,337 @@ func Test_GivenAnyList_WhenReverseCalledTwice_ThenOriginalRestored(t *testing.T)
 	assert.NotNil(t, list.head)
 	assert.NotNil(t, list.tail)
 	assert.Nil(t, list.tail.next)
-}
\ No newline at end of file
+}
+
+func Test_GivenReversedList_WhenRangingBackwardAndModifying_ThenPrevPointersFollowNewOrder(t *testing.T) {
+	// Given
+	list := makeList2(1, 2, 3, 4)
+	list.Reverse()
+
+	// When
+	backward := slices.Collect(list.Backward())
+	list.Insert(list.head, Node[int]{value: 7})
+	afterInsert := toReverseSlice(&list)
+	list.Delete(2, false)
+
+	// Then
+	assert.Equal(t, []int{1, 2, 3, 4}, backward)
+	assert.Equal(t, []int{1, 2, 3, 7, 4}, afterInsert)
+	assert.Equal(t, []int{4, 7, 3, 1}, toSlice(&list))
+	assert.Equal(t, []int{1, 3, 7, 4}, slices.Collect(list.Backward()))
+	assert.Nil(t, list.head.prev)
+	assert.Nil(t, list.tail.next)
+}
+
+// SORT - COMPARATOR
+
+type record struct {
+	key int
+	id  string
+}
+
+func makeRecords(values ...record) LinkedList2[record] {
+	var list LinkedList2[record]
+	for _, v := range values {
+		list.AddInTail(Node[record]{value: v})
+	}
+	return list
+}
+
+func recordIds(list *LinkedList2[record]) []string {
+	res := make([]string, 0, list.Count())
+	for n := list.head; n != nil; n = n.next {
+		res = append(res, n.value.id)
+	}
+	return res
+}
+
+func Test_GivenDescendingComparator_WhenSortCalled_ThenBecomesDescending(t *testing.T) {
+	// Given
+	list := makeList2(3, 1, 4, 1, 5, 9, 2, 6)
+
+	// When
+	list.Sort(func(a, b int) int { return cmp.Compare(b, a) })
+
+	// Then
+	assert.Equal(t, []int{9, 6, 5, 4, 3, 2, 1, 1}, toSlice(&list))
+	assert.Equal(t, 8, list.Count())
+}
+
+func Test_GivenUnsortedList_WhenSortCalled_ThenPrevPointersMatchForwardOrder(t *testing.T) {
+	// Given
+	list := makeList2(5, 2, 8, 1, 9, 3)
+
+	// When
+	list.Sort(cmp.Compare[int])
+
+	// Then
+	assert.Equal(t, []int{1, 2, 3, 5, 8, 9}, toSlice(&list))
+	assert.Equal(t, []int{9, 8, 5, 3, 2, 1}, toReverseSlice(&list))
+	assert.Nil(t, list.head.prev)
+	assert.Nil(t, list.tail.next)
+}
+
+func Test_GivenRecordsWithEqualKeys_WhenSortedByKey_ThenOriginalOrderOfEqualKeysKept(t *testing.T) {
+	// Given
+	list := makeRecords(
+		record{2, "a"}, record{1, "b"}, record{2, "c"}, record{1, "d"},
+		record{3, "e"}, record{2, "f"}, record{1, "g"},
+	)
+
+	// When
+	list.Sort(func(a, b record) int { return cmp.Compare(a.key, b.key) })
+
+	// Then
+	assert.Equal(t, []string{"b", "d", "g", "a", "c", "f", "e"}, recordIds(&list))
+	assert.Equal(t, 7, list.Count())
+}
+
+func Test_GivenAllEqualRecords_WhenSortCalled_ThenOrderUnchanged(t *testing.T) {
+	// Given
+	list := makeRecords(record{1, "x"}, record{1, "y"}, record{1, "z"})
+
+	// When
+	list.Sort(func(a, b record) int { return cmp.Compare(a.key, b.key) })
+
+	// Then
+	assert.Equal(t, []string{"x", "y", "z"}, recordIds(&list))
+	assert.Equal(t, "z", list.tail.value.id)
+}
+
+func Test_GivenManyRecordsWithFewKeys_WhenSorted_ThenSameOrderAsStableSliceSort(t *testing.T) {
+	// Given
+	var records []record
+	for i := 0; i < 200; i++ {
+		records = append(records, record{key: (i * 7919) % 5, id: fmt.Sprint(i)})
+	}
+	list := makeRecords(records...)
+	byKey := func(a, b record) int { return cmp.Compare(a.key, b.key) }
+
+	// When
+	list.Sort(byKey)
+
+	// Then
+	slices.SortStableFunc(records, byKey)
+	expected := make([]string, 0, len(records))
+	for _, r := range records {
+		expected = append(expected, r.id)
+	}
+	assert.Equal(t, expected, recordIds(&list))
+	assert.Equal(t, expected[len(expected)-1], list.tail.value.id)
+}
+
+func Test_GivenNilComparator_WhenSortCalled_ThenErrorAndListUnchanged(t *testing.T) {
+	// Given
+	list := makeList2(3, 1, 2)
+	version := list.version
+
+	// When
+	err := list.Sort(nil)
+
+	// Then
+	assert.EqualError(t, err, "nil comparator")
+	assert.Equal(t, []int{3, 1, 2}, toSlice(&list))
+	assert.Equal(t, []int{2, 1, 3}, toReverseSlice(&list))
+	assert.Equal(t, version, list.version)
+}
+
+func Test_GivenStringList_WhenFindingAndDeleting_ThenEqualityIsUsed(t *testing.T) {
+	// Given
+	var list LinkedList2[string]
+	for _, v := range []string{"b", "a", "b", "c"} {
+		list.AddInTail(Node[string]{value: v})
+	}
+
+	// When
+	node, err := list.Find("c")
+	list.Delete("b", true)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "c", node.value)
+	assert.Equal(t, 2, list.Count())
+	assert.Equal(t, "a", list.head.value)
+	assert.Equal(t, "c", list.tail.value)
+}
+
+// ALL / BACKWARD
+
+func Test_GivenMultiNodeList_WhenRangingOverAll_ThenValuesFromHeadToTail(t *testing.T) {
+	// Given
+	list := makeList2(1, 2, 3)
+
+	// When
+	values := slices.Collect(list.All())
+
+	// Then
+	assert.Equal(t, []int{1, 2, 3}, values)
+	assert.Equal(t, 3, list.Count())
+}
+
+func Test_GivenMultiNodeList_WhenRangingOverBackward_ThenValuesFromTailToHead(t *testing.T) {
+	// Given
+	list := makeList2(1, 2, 3)
+
+	// When
+	values := slices.Collect(list.Backward())
+
+	// Then
+	assert.Equal(t, []int{3, 2, 1}, values)
+	assert.Equal(t, []int{1, 2, 3}, toSlice(&list))
+}
+
+func Test_GivenEmptyList_WhenRangingOverAllAndBackward_ThenNothingYielded(t *testing.T) {
+	// Given
+	list := LinkedList2[int]{}
+
+	// When
+	forward := slices.Collect(list.All())
+	backward := slices.Collect(list.Backward())
+
+	// Then
+	assert.Empty(t, forward)
+	assert.Empty(t, backward)
+}
+
+func Test_GivenMultiNodeList_WhenBreakingOutOfBackward_ThenIterationStops(t *testing.T) {
+	// Given
+	list := makeList2(1, 2, 3, 4)
+	var visited []int
+
+	// When
+	for v := range list.Backward() {
+		visited = append(visited, v)
+		if v == 3 {
+			break
+		}
+	}
+
+	// Then
+	assert.Equal(t, []int{4, 3}, visited)
+}
+
+// MODIFICATION DURING ITERATION
+
+func assertPanicsWhenModifiedDuringAll(t *testing.T, list *LinkedList2[int], mutate func(*LinkedList2[int])) {
+	assert.PanicsWithError(t, errModifiedDuringIteration.Error(), func() {
+		for range list.All() {
+			mutate(list)
+		}
+	})
+}
+
+func Test_GivenIteration_WhenAddInTailCalled_ThenPanics(t *testing.T) {
+	// Given
+	list := makeList2(1, 2, 3)
+
+	// When / Then
+	assertPanicsWhenModifiedDuringAll(t, &list, func(l *LinkedList2[int]) { l.AddInTail(Node[int]{value: 4}) })
+}
+
+func Test_GivenIteration_WhenInsertCalled_ThenPanics(t *testing.T) {
+	// Given
+	list := makeList2(1, 2, 3)
+
+	// When / Then
+	assertPanicsWhenModifiedDuringAll(t, &list, func(l *LinkedList2[int]) { l.Insert(l.head, Node[int]{value: 4}) })
+}
+
+func Test_GivenIteration_WhenInsertFirstCalled_ThenPanics(t *testing.T) {
+	// Given
+	list := makeList2(1, 2, 3)
+
+	// When / Then
+	assertPanicsWhenModifiedDuringAll(t, &list, func(l *LinkedList2[int]) { l.InsertFirst(Node[int]{value: 0}) })
+}
+
+func Test_GivenIteration_WhenDeleteRemovesValue_ThenPanics(t *testing.T) {
+	// Given
+	list := makeList2(1, 2, 3)
+
+	// When / Then
+	assertPanicsWhenModifiedDuringAll(t, &list, func(l *LinkedList2[int]) { l.Delete(3, false) })
+}
+
+func Test_GivenIteration_WhenDeleteFindsNothing_ThenIterationCompletes(t *testing.T) {
+	// Given
+	list := makeList2(1, 2, 3)
+	var visited []int
+
+	// When
+	for v := range list.All() {
+		list.Delete(99, true)
+		visited = append(visited, v)
+	}
+
+	// Then
+	assert.Equal(t, []int{1, 2, 3}, visited)
+}
+
+func Test_GivenIteration_WhenCleanCalled_ThenPanics(t *testing.T) {
+	// Given
+	list := makeList2(1, 2, 3)
+
+	// When / Then
+	assertPanicsWhenModifiedDuringAll(t, &list, func(l *LinkedList2[int]) { l.Clean() })
+}
+
+func Test_GivenIteration_WhenSortCalled_ThenPanics(t *testing.T) {
+	// Given
+	list := makeList2(3, 2, 1)
+
+	// When / Then
+	assertPanicsWhenModifiedDuringAll(t, &list, func(l *LinkedList2[int]) { l.Sort(cmp.Compare[int]) })
+}
+
+func Test_GivenIteration_WhenReverseCalled_ThenPanics(t *testing.T) {
+	// Given
+	list := makeList2(1, 2, 3)
+
+	// When / Then
+	assertPanicsWhenModifiedDuringAll(t, &list, func(l *LinkedList2[int]) { l.Reverse() })
+}
+
+func Test_GivenBackwardIteration_WhenListModified_ThenPanics(t *testing.T) {
+	// Given
+	list := makeList2(1, 2, 3)
+
+	// When / Then
+	assert.PanicsWithError(t, errModifiedDuringIteration.Error(), func() {
+		for range list.Backward() {
+			list.AddInTail(Node[int]{value: 4})
+		}
+	})
+}
+
+func Test_GivenDummyNodeList_WhenModifiedWithSentinelMethods_ThenIterationPanics(t *testing.T) {
+	// Given
+	mutations := []func(*LinkedList2[int]){
+		func(l *LinkedList2[int]) { l.AddInTail2(Node[int]{value: 9}) },
+		func(l *LinkedList2[int]) { l.InsertFirst2(Node[int]{value: 9}) },
+		func(l *LinkedList2[int]) { l.Insert2(nil, Node[int]{value: 9}) },
+		func(l *LinkedList2[int]) { l.Delete2(1, false) },
+	}
+
+	for _, mutate := range mutations {
+		var list LinkedList2[int]
+		list.AddInTail2(Node[int]{value: 1})
+		list.AddInTail2(Node[int]{value: 2})
+
+		// When / Then
+		assertPanicsWhenModifiedDuringAll(t, &list, mutate)
+	}
+}
+
+func Test_GivenFinishedIteration_WhenListModified_ThenNewIterationWorks(t *testing.T) {
+	// Given
+	list := makeList2(1, 2)
+	for range list.All() {
+	}
+
+	// When
+	list.AddInTail(Node[int]{value: 3})
+
+	// Then
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(list.All()))
+}
@@ -3,22 +3,27 @@ package doubly_linked_list
 import (
 	// "os"
 	// "reflect"
+	"errors"
 	"fmt"
+	"iter"
 )
 
-type Node struct {
-	prev  *Node
-	next  *Node
-	value int
+var errModifiedDuringIteration = errors.New("collection was modified during iteration")
+
+type Node[T comparable] struct {
+	prev  *Node[T]
+	next  *Node[T]
+	value T
 }
 
-type LinkedList2 struct {
-	head  *Node
-	tail  *Node
-	count int
+type LinkedList2[T comparable] struct {
+	head    *Node[T]
+	tail    *Node[T]
+	count   int
+	version int
 }
 
-func (l *LinkedList2) AddInTail(item Node) {
+func (l *LinkedList2[T]) AddInTail(item Node[T]) {
 	if l.head == nil {
 		l.head = &item
 		l.head.next = nil
@@ -31,25 +36,26 @@ func (l *LinkedList2) AddInTail(item Node) {
 	l.tail = &item
 	l.tail.next = nil
 	l.count++
+	l.version++
 }
 
-func (l *LinkedList2) Count() int {
+func (l *LinkedList2[T]) Count() int {
 	return l.count
 }
 
 // error не nil, если узел не найден
-func (l *LinkedList2) Find(n int) (Node, error) {
+func (l *LinkedList2[T]) Find(n T) (Node[T], error) {
 	for temp := l.head; temp != nil; temp = temp.next {
 		if temp.value == n {
 			return *temp, nil
 		}
 	}
 
-	return Node{value: -1, next: nil}, fmt.Errorf("node with value %d not found", n)
+	return Node[T]{}, fmt.Errorf("node with value %v not found", n)
 }
 
-func (l *LinkedList2) FindAll(n int) []Node {
-	var nodes []Node
+func (l *LinkedList2[T]) FindAll(n T) []Node[T] {
+	var nodes []Node[T]
 
 	for temp := l.head; temp != nil; temp = temp.next {
 		if temp.value == n {
@@ -60,9 +66,14 @@ func (l *LinkedList2) FindAll(n int) []Node {
 	return nodes
 }
 
-func (l *LinkedList2) Delete(n int, all bool) {
+func (l *LinkedList2[T]) Delete(n T, all bool) {
+	countBefore := l.count
 	l.head = l.DeleteRec(l.head, n, 0, all)
 
+	if l.count != countBefore {
+		l.version++
+	}
+
 	if l.head == nil {
 		l.tail = nil
 	} else {
@@ -70,7 +81,7 @@ func (l *LinkedList2) Delete(n int, all bool) {
 	}
 }
 
-func (l *LinkedList2) DeleteRec(temp *Node, n, delCount int, all bool) *Node {
+func (l *LinkedList2[T]) DeleteRec(temp *Node[T], n T, delCount int, all bool) *Node[T] {
 	if temp == nil {
 		return nil
 	}
@@ -97,7 +108,7 @@ func (l *LinkedList2) DeleteRec(temp *Node, n, delCount int, all bool) *Node {
 	return temp
 }
 
-func (l *LinkedList2) Insert(after *Node, add Node) {
+func (l *LinkedList2[T]) Insert(after *Node[T], add Node[T]) {
 	if after == nil {
         l.InsertFirst(add)
         return
@@ -112,19 +123,21 @@ func (l *LinkedList2) Insert(after *Node, add Node) {
 
 	after.next = &add
 	l.count++
+	l.version++
 
 	if add.next == nil {
 		l.tail = &add
 	}
 }
 
-func (l *LinkedList2) InsertFirst(first Node) {
+func (l *LinkedList2[T]) InsertFirst(first Node[T]) {
 	if l.head == nil {
 		first.prev = nil
 		first.next = nil
 		l.head = &first
 		l.tail = &first
 		l.count++
+		l.version++
 		return
 	}
 
@@ -133,10 +146,44 @@ func (l *LinkedList2) InsertFirst(first Node) {
 	l.head.prev = &first
 	l.head = &first
 	l.count++
+	l.version++
 }
 
-func (l *LinkedList2) Clean() {
+func (l *LinkedList2[T]) Clean() {
 	l.head = nil
 	l.tail = nil
 	l.count = 0
+	l.version++
+}
+
+// values from head to tail, the list itself is not changed. Panics if the list is modified before the iteration is over
+func (l *LinkedList2[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		version := l.version
+		for temp := l.head; temp != nil; temp = temp.next {
+			if !yield(temp.value) {
+				return
+			}
+			l.checkVersion(version)
+		}
+	}
+}
+
+// values from tail to head using the prev pointers, same fail-fast behavior as All
+func (l *LinkedList2[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		version := l.version
+		for temp := l.tail; temp != nil; temp = temp.prev {
+			if !yield(temp.value) {
+				return
+			}
+			l.checkVersion(version)
+		}
+	}
+}
+
+func (l *LinkedList2[T]) checkVersion(version int) {
+	if l.version != version {
+		panic(errModifiedDuringIteration)
+	}
 }
@@ -217,4 +217,124 @@ func Test_GivenInvalidIndex_WhenRemove_ThenErrorAndNoMutation(t *testing.T) {
 	assert.Equal(t, oldCnt, da.count)
 	assert.Equal(t, oldCap, da.capacity)
 	assert.Equal(t, before, toSlice(da))
-}
\ No newline at end of file
+}
+
+// ALL / BACKWARD
+
+func Test_GivenArray_WhenRangingOverAll_ThenIndexValuePairsInOrder(t *testing.T) {
+	// Given
+	da := makeDyn(10, 20, 30)
+	var indices, values []int
+
+	// When
+	for i, v := range da.All() {
+		indices = append(indices, i)
+		values = append(values, v)
+	}
+
+	// Then
+	assert.Equal(t, []int{0, 1, 2}, indices)
+	assert.Equal(t, []int{10, 20, 30}, values)
+	assert.Equal(t, 3, da.count)
+}
+
+func Test_GivenArray_WhenRangingOverBackward_ThenPairsInReverseOrder(t *testing.T) {
+	// Given
+	da := makeDyn(10, 20, 30)
+	var indices, values []int
+
+	// When
+	for i, v := range da.Backward() {
+		indices = append(indices, i)
+		values = append(values, v)
+	}
+
+	// Then
+	assert.Equal(t, []int{2, 1, 0}, indices)
+	assert.Equal(t, []int{30, 20, 10}, values)
+}
+
+func Test_GivenArrayAfterRemove_WhenRangingOverAll_ThenOnlyLogicalContentVisited(t *testing.T) {
+	// Given
+	da := makeDyn(1, 2, 3)
+	_ = da.Remove(2)
+	var values []int
+
+	// When
+	for _, v := range da.All() {
+		values = append(values, v)
+	}
+
+	// Then
+	assert.Equal(t, []int{1, 2}, values)
+}
+
+// MODIFICATION DURING ITERATION
+
+func assertPanicsWhenModifiedDuringAll(t *testing.T, da *DynArray[int], mutate func(*DynArray[int])) {
+	assert.PanicsWithError(t, errModifiedDuringIteration.Error(), func() {
+		for range da.All() {
+			mutate(da)
+		}
+	})
+}
+
+func Test_GivenIteration_WhenAppending_ThenPanics(t *testing.T) {
+	// Given
+	da := makeDyn(1, 2, 3)
+
+	// When/Then
+	assertPanicsWhenModifiedDuringAll(t, da, func(d *DynArray[int]) { d.Append(4) })
+}
+
+func Test_GivenIteration_WhenInserting_ThenPanics(t *testing.T) {
+	// Given
+	da := makeDyn(1, 2, 3)
+
+	// When/Then
+	assertPanicsWhenModifiedDuringAll(t, da, func(d *DynArray[int]) { _ = d.Insert(0, 0) })
+}
+
+func Test_GivenIteration_WhenRemoving_ThenPanics(t *testing.T) {
+	// Given
+	da := makeDyn(1, 2, 3)
+
+	// When/Then
+	assertPanicsWhenModifiedDuringAll(t, da, func(d *DynArray[int]) { _ = d.Remove(0) })
+}
+
+func Test_GivenIteration_WhenReinitialized_ThenPanics(t *testing.T) {
+	// Given
+	da := makeDyn(1, 2, 3)
+
+	// When/Then
+	assertPanicsWhenModifiedDuringAll(t, da, func(d *DynArray[int]) { d.Init() })
+}
+
+func Test_GivenIteration_WhenInvalidRemoveFails_ThenIterationCompletes(t *testing.T) {
+	// Given
+	da := makeDyn(1, 2, 3)
+	var values []int
+
+	// When
+	for _, v := range da.All() {
+		_ = da.Remove(99)
+		_ = da.Insert(0, -1)
+		values = append(values, v)
+	}
+
+	// Then
+	assert.Equal(t, []int{1, 2, 3}, values)
+}
+
+func Test_GivenBackwardIteration_WhenAppending_ThenPanics(t *testing.T) {
+	// Given
+	da := makeDyn(1, 2, 3)
+
+	// When/Then
+	assert.PanicsWithError(t, errModifiedDuringIteration.Error(), func() {
+		for range da.Backward() {
+			da.Append(4)
+		}
+	})
+}
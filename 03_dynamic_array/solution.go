@@ -2,7 +2,9 @@ package dynamic_array
 
 import (
 	// "os"
+	"errors"
 	"fmt"
+	"iter"
 )
 
 const (
@@ -12,14 +14,18 @@ const (
 	SHRINK_THRESHOLD = 0.5
 )
 
+var errModifiedDuringIteration = errors.New("collection was modified during iteration")
+
 type DynArray[T any] struct {
 	count    int
 	capacity int
 	array    []T
+	version  int
 }
 
 func (da *DynArray[T]) Init() {
 	da.count = 0
+	da.version++
 	da.MakeArray(MIN_CAPACITY)
 }
 
@@ -41,6 +47,39 @@ func (da *DynArray[T]) Append(itm T) {
 	}
 	da.array[da.count] = itm
 	da.count++
+	da.version++
+}
+
+// index and value pairs in the same form as slices.All, only the first count elements are visited.
+// Panics if the array is modified before the iteration is over
+func (da *DynArray[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		version := da.version
+		for i := 0; i < da.count; i++ {
+			if !yield(i, da.array[i]) {
+				return
+			}
+			da.checkVersion(version)
+		}
+	}
+}
+
+func (da *DynArray[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		version := da.version
+		for i := da.count - 1; i >= 0; i-- {
+			if !yield(i, da.array[i]) {
+				return
+			}
+			da.checkVersion(version)
+		}
+	}
+}
+
+func (da *DynArray[T]) checkVersion(version int) {
+	if da.version != version {
+		panic(errModifiedDuringIteration)
+	}
 }
 
 func (da *DynArray[T]) GetItem(index int) (T, error) {
@@ -65,6 +104,7 @@ func (da *DynArray[T]) Insert(itm T, index int) error {
 	}
 	da.array[index] = itm
 	da.count++
+	da.version++
 	return nil
 }
 
@@ -77,6 +117,7 @@ func (da *DynArray[T]) Remove(index int) error {
 
 	copy(da.array[index:], da.array[index+1:da.count])
 	da.count--
+	da.version++
 
 	if float64(da.count) / float64(da.capacity) >= SHRINK_THRESHOLD {
 		return nil
@@ -1,6 +1,7 @@
 package stack
 
 import (
+	"slices"
 	"testing"
 	"github.com/stretchr/testify/assert"
 )
@@ -1135,4 +1136,30 @@ func mustGetMin(stack *MinStack[int]) int {
 		panic(err)
 	}
 	return curMin
-}
\ No newline at end of file
+}
+
+// ALL
+
+func Test_GivenStack_WhenRangingOverAll_ThenValuesFromTopToBottomAndStackUnchanged(t *testing.T) {
+	// Given
+	stack := makeStack(1, 2, 3)
+
+	// When
+	values := slices.Collect(stack.All())
+
+	// Then
+	assert.Equal(t, []int{3, 2, 1}, values)
+	assert.Equal(t, 3, stack.Size())
+	assert.Equal(t, []int{3, 2, 1}, popAll(&stack))
+}
+
+func Test_GivenEmptyStack_WhenRangingOverAll_ThenNothingYielded(t *testing.T) {
+	// Given
+	stack := Stack[int]{}
+
+	// When
+	values := slices.Collect(stack.All())
+
+	// Then
+	assert.Empty(t, values)
+}
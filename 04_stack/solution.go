@@ -3,6 +3,7 @@ package stack
 import (
 	// "os"
 	"errors"
+	"iter"
 )
 
 type Node[T any] struct {
@@ -43,3 +44,14 @@ func (st *Stack[T]) Push(itm T) {
 	st.head = &newTop
 	st.count++
 }
+
+// values from top to bottom - the order Pop would return them, but without popping
+func (st *Stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for temp := st.head; temp != nil; temp = temp.next {
+			if !yield(temp.value) {
+				return
+			}
+		}
+	}
+}
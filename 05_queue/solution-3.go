@@ -1,6 +1,7 @@
 package queue
 
 import (
+	"slices"
 	"testing"
 	"github.com/stretchr/testify/assert"
 )
@@ -799,4 +800,30 @@ func Test_GivenReversedQueue_WhenDequeuing_ThenRemovesFromFront(t *testing.T) {
 	// Then
 	assert.Equal(t, 5, val)
 	assert.Equal(t, []int{4, 3, 2, 1}, dequeueAll(&queue))
-}
\ No newline at end of file
+}
+
+// ALL
+
+func Test_GivenQueue_WhenRangingOverAll_ThenValuesFromHeadToTailAndQueueUnchanged(t *testing.T) {
+	// Given
+	queue := makeQueue(1, 2, 3)
+
+	// When
+	values := slices.Collect(queue.All())
+
+	// Then
+	assert.Equal(t, []int{1, 2, 3}, values)
+	assert.Equal(t, 3, queue.Size())
+	assert.Equal(t, []int{1, 2, 3}, dequeueAll(&queue))
+}
+
+func Test_GivenEmptyQueue_WhenRangingOverAll_ThenNothingYielded(t *testing.T) {
+	// Given
+	queue := Queue[int]{}
+
+	// When
+	values := slices.Collect(queue.All())
+
+	// Then
+	assert.Empty(t, values)
+}
@@ -3,6 +3,7 @@ package queue
 import (
 	"os"
 	"errors"
+	"iter"
 )
 
 type Node[T any] struct {
@@ -47,3 +48,14 @@ func (q *Queue[T]) Enqueue(itm T) {
 	}
 	q.size++
 }
+
+// values from head to tail - the order Dequeue would return them, but without dequeuing
+func (q *Queue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for temp := q.head; temp != nil; temp = temp.next {
+			if !yield(temp.value) {
+				return
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package deque
 
 import (
+	"slices"
 	"testing"
 	"github.com/stretchr/testify/assert"
 )
@@ -321,4 +322,45 @@ func Test_GivenDeque_WhenComplexSequenceOfOperations_ThenMaintainsIntegrity(t *t
 	assert.Equal(t, 30, val3)
 	assert.Equal(t, 4, deque.Size())
 	assert.Equal(t, []int{2, 5, 10, 40}, toSlice(&deque))
-}
\ No newline at end of file
+}
+
+// ALL / BACKWARD
+
+func Test_GivenDeque_WhenRangingOverAll_ThenValuesFromFrontToTailAndDequeUnchanged(t *testing.T) {
+	// Given
+	deque := makeDeque(1, 2, 3)
+
+	// When
+	values := slices.Collect(deque.All())
+
+	// Then
+	assert.Equal(t, []int{1, 2, 3}, values)
+	assert.Equal(t, 3, deque.Size())
+}
+
+func Test_GivenDeque_WhenRangingOverBackward_ThenValuesFromTailToFront(t *testing.T) {
+	// Given
+	deque := makeDeque(1, 2, 3)
+
+	// When
+	values := slices.Collect(deque.Backward())
+
+	// Then
+	assert.Equal(t, []int{3, 2, 1}, values)
+	assert.Equal(t, []int{1, 2, 3}, toSlice(&deque))
+}
+
+func Test_GivenDeque_WhenBreakingOutOfAll_ThenIterationStops(t *testing.T) {
+	// Given
+	deque := makeDeque(1, 2, 3)
+	var visited []int
+
+	// When
+	for v := range deque.All() {
+		visited = append(visited, v)
+		break
+	}
+
+	// Then
+	assert.Equal(t, []int{1}, visited)
+}
@@ -3,6 +3,7 @@ package main
 import (
   "os"
   "errors"
+  "iter"
 )
 
 type Node[T any] struct {
@@ -90,3 +91,25 @@ func (d *Deque[T]) RemoveTail() (T, error) {
   d.size--
   return result, nil
 }
+
+// values from front to tail without removing them
+func (d *Deque[T]) All() iter.Seq[T] {
+  return func(yield func(T) bool) {
+    for temp := d.head; temp != nil; temp = temp.next {
+      if !yield(temp.value) {
+        return
+      }
+    }
+  }
+}
+
+// values from tail to front without removing them
+func (d *Deque[T]) Backward() iter.Seq[T] {
+  return func(yield func(T) bool) {
+    for temp := d.tail; temp != nil; temp = temp.prev {
+      if !yield(temp.value) {
+        return
+      }
+    }
+  }
+}
@@ -3,6 +3,10 @@ package ordered_list
 import (
 	"constraints"
 	"errors"
+	"iter"
+	"math/rand"
+	"slices"
+	"time"
 )
 
 /*
@@ -10,21 +14,27 @@ import (
 *
 * The idea here is that we pick a node and find the node after it with different value and relink the pointers. While skipping
 * nodes we also decrement the nodes counter. There is also an edge case when the next node with different value is null, in that
-* case we do not relink the previous pointer to avoid null dereference.
+* case we do not relink the previous pointer to avoid null dereference - instead the node becomes the new tail, otherwise the tail
+* would still point to a removed duplicate and Backward would start from it.
  */
-func (l *OrderedList[T]) RemoveDuplicates() {
+func (l *listCore[T, C]) RemoveDuplicates() {
 	if l.head == nil || l.head == l.tail {
 		return
 	}
 
+	l.version++
+	l.finger = nil
+
 	for temp := l.head; temp != nil; {
 		innerTemp := temp.next
-		for ; innerTemp != nil && innerTemp.value == temp.value; innerTemp = innerTemp.next {
+		for ; innerTemp != nil && l.equal(innerTemp.value, temp.value); innerTemp = innerTemp.next {
 			l.count--
 		}
 		temp.next = innerTemp
 		if innerTemp != nil {
 			innerTemp.prev = temp
+		} else {
+			l.tail = temp
 		}
 		temp = innerTemp
 	}
@@ -39,7 +49,7 @@ func (l *OrderedList[T]) RemoveDuplicates() {
 * the tail and that's it.
 */
 
-func (l *OrderedList[T]) Merge(toMerge *OrderedList[T]) error {
+func (l *listCore[T, C]) merge(toMerge *listCore[T, C]) error {
 	if l._ascending != toMerge._ascending {
 		return errors.New("invalid ascending flag for given list")
 	}
@@ -48,13 +58,18 @@ func (l *OrderedList[T]) Merge(toMerge *OrderedList[T]) error {
 		return errors.New("can not merge with itself")
 	}
 
+	// both lists share the nodes after the merge, so iterating any of them is no longer valid
+	toMerge.version++
+
 	if l.head == nil {
-		*l = *toMerge
+		l.head, l.tail, l.count = toMerge.head, toMerge.tail, toMerge.count
+		l.version++
 		return nil
 	}
 
 	l.head = l.mergeRec(l.head, toMerge.head)
 	l.count += toMerge.count
+	l.version++
 
 	temp := l.head
 	for ; temp.next != nil; temp = temp.next {}
@@ -64,7 +79,7 @@ func (l *OrderedList[T]) Merge(toMerge *OrderedList[T]) error {
 	return nil
 }
 
-func (l *OrderedList[T]) mergeRec(list1, list2 *Node[T]) *Node[T] {
+func (l *listCore[T, C]) mergeRec(list1, list2 *Node[T]) *Node[T] {
 	if list1 == nil {
 		return list2
 	}
@@ -99,7 +114,7 @@ func (l *OrderedList[T]) mergeRec(list1, list2 *Node[T]) *Node[T] {
 * -> all nodes were traversed and we return true. In all other cases - false.
 */
 
-func (l *OrderedList[T]) ContainsSublist(subList *OrderedList[T]) (bool, error) {
+func (l *listCore[T, C]) containsSublist(subList *listCore[T, C]) (bool, error) {
 	if l.head == nil {
 		return false, errors.New("empty list")
 	}
@@ -117,15 +132,27 @@ func (l *OrderedList[T]) ContainsSublist(subList *OrderedList[T]) (bool, error)
 	}
 
 	start := l.head
-	for ; start != nil && start.value != subList.head.value; start = start.next {}
+	for ; start != nil && !l.equal(start.value, subList.head.value); start = start.next {}
 
 	if start == nil {
 		return false, nil
 	}
 
+	// if the list has more copies of the first value than the sublist starts with, only the last of them can be the start
+	leading, run := 0, 0
+	for temp := subList.head; temp != nil && l.equal(temp.value, subList.head.value); temp = temp.next {
+		leading++
+	}
+	for temp := start; temp != nil && l.equal(temp.value, start.value); temp = temp.next {
+		run++
+	}
+	for ; run > leading; run-- {
+		start = start.next
+	}
+
 	subPointer := subList.head
 	for ; subPointer != nil && start != nil; subPointer = subPointer.next {
-		if subPointer.value != start.value {
+		if !l.equal(subPointer.value, start.value) {
 			return false, nil
 		}
 		start = start.next
@@ -143,7 +170,7 @@ func (l *OrderedList[T]) ContainsSublist(subList *OrderedList[T]) (bool, error)
 * our baseline algorithm.
 */
 
-func (l *OrderedList[T]) MostFrequent() (T, error) {
+func (l *listCore[T, C]) MostFrequent() (T, error) {
 	var result T
 
 	if l.head == nil {
@@ -155,7 +182,7 @@ func (l *OrderedList[T]) MostFrequent() (T, error) {
 
 	for temp := l.head; temp != nil; {
 		window, end := 1, temp.next
-		for ; end != nil && temp.value == end.value; end = end.next {
+		for ; end != nil && l.equal(temp.value, end.value); end = end.next {
 			window++
 		}
 		temp = end
@@ -172,6 +199,527 @@ func (l *OrderedList[T]) MostFrequent() (T, error) {
 	return result, nil
 }
 
+/*
+* 7. Ordered list - range queries
+*
+* Bounds are positions in the list order, same as FindPosition - LowerBound is the first position where the value could be inserted,
+* so the first element which is not before it, UpperBound is the first element which is after it. Floor, Ceiling and the range
+* on the other side talk about values - Floor is the greatest value <= v, Ceiling the least value >= v and Range(lo, hi) yields
+* everything with lo <= value <= hi, no matter in which direction the list is sorted. In the ascending list the floor is right before
+* the upper bound and the ceiling is at the lower bound, in the descending list it is the other way round. And because the list is
+* sorted, the range is one contiguous run - it starts at the bound of the value which comes first in the list order and stops at
+* the first element outside of it.
+*/
+
+func (l *listCore[T, C]) LowerBound(value T) int {
+	_, position := l.lowerBoundNode(value)
+	return position
+}
+
+func (l *listCore[T, C]) UpperBound(value T) int {
+	_, position := l.upperBoundNode(value)
+	return position
+}
+
+// the greatest value less than or equal to the given one
+func (l *listCore[T, C]) Floor(value T) (T, error) {
+	if l._ascending {
+		upper, _ := l.upperBoundNode(value)
+		return l.valueBefore(upper)
+	}
+	lower, _ := l.lowerBoundNode(value)
+	return nodeValue(lower)
+}
+
+// the least value greater than or equal to the given one
+func (l *listCore[T, C]) Ceiling(value T) (T, error) {
+	if l._ascending {
+		lower, _ := l.lowerBoundNode(value)
+		return nodeValue(lower)
+	}
+	upper, _ := l.upperBoundNode(value)
+	return l.valueBefore(upper)
+}
+
+func (l *listCore[T, C]) CountInRange(lo T, hi T) int {
+	count := 0
+	for range l.Range(lo, hi) {
+		count++
+	}
+	return count
+}
+
+// values with lo <= value <= hi in the list order, panics if the list is modified before the iteration is over
+func (l *listCore[T, C]) Range(lo T, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if l.cmp.compare(lo, hi) > 0 {
+			return
+		}
+
+		first, last := lo, hi
+		if !l._ascending {
+			first, last = hi, lo
+		}
+
+		version := l.version
+		start, _ := l.lowerBoundNode(first)
+
+		for temp := start; temp != nil && l.isPivot(temp, last, false); temp = temp.next {
+			if !yield(temp.value) {
+				return
+			}
+			l.checkVersion(version)
+		}
+	}
+}
+
+// first node which is not before the value and its position, nil and count if there is no such node
+func (l *listCore[T, C]) lowerBoundNode(value T) (*Node[T], int) {
+	position := 0
+	temp := l.head
+
+	for ; temp != nil && !l.isPivot(temp, value, true); temp = temp.next {
+		position++
+	}
+
+	return temp, position
+}
+
+// first node which is after the value and its position
+func (l *listCore[T, C]) upperBoundNode(value T) (*Node[T], int) {
+	position := 0
+	temp := l.head
+
+	for ; temp != nil && (!l.isPivot(temp, value, true) || l.equal(temp.value, value)); temp = temp.next {
+		position++
+	}
+
+	return temp, position
+}
+
+// value of the node in front of the bound, where the nil bound is the end of the list
+func (l *listCore[T, C]) valueBefore(bound *Node[T]) (T, error) {
+	if bound == nil {
+		return nodeValue(l.tail)
+	}
+	return nodeValue(bound.prev)
+}
+
+func nodeValue[T any](node *Node[T]) (T, error) {
+	var result T
+
+	if node == nil {
+		return result, errors.New("not found")
+	}
+
+	return node.value, nil
+}
+
+/*
+* 7. Ordered list - set algebra
+*
+* All of them are the same walk as in Merge - two pointers, always take the value which comes first in the list order. But instead of
+* single nodes we take whole runs of equal values and count how long the run is in each list, the operation then only decides how
+* many copies go to the result. For the multiset it is the classic max for union, min for intersection, a - b for difference and
+* |a - b| for the symmetric one, with Distinct both counts are first cut to 1 and the same formulas give the plain set operations.
+* The result is a new list and the nodes are appended to its tail, so the whole thing stays linear. The inputs are never modified.
+*/
+
+type Multiplicity int
+
+const (
+	Distinct Multiplicity = iota
+	Multiset
+)
+
+func (l *listCore[T, C]) union(other *listCore[T, C], mode Multiplicity) (*listCore[T, C], error) {
+	return l.combine(other, mode, func(inFirst, inSecond int) int { return max(inFirst, inSecond) })
+}
+
+func (l *listCore[T, C]) intersection(other *listCore[T, C], mode Multiplicity) (*listCore[T, C], error) {
+	return l.combine(other, mode, func(inFirst, inSecond int) int { return min(inFirst, inSecond) })
+}
+
+func (l *listCore[T, C]) difference(other *listCore[T, C], mode Multiplicity) (*listCore[T, C], error) {
+	return l.combine(other, mode, func(inFirst, inSecond int) int { return max(inFirst-inSecond, 0) })
+}
+
+func (l *listCore[T, C]) symmetricDifference(other *listCore[T, C], mode Multiplicity) (*listCore[T, C], error) {
+	return l.combine(other, mode, func(inFirst, inSecond int) int { return max(inFirst-inSecond, inSecond-inFirst) })
+}
+
+// with Multiset every value has to be in the other list at least as many times as in this one
+func (l *listCore[T, C]) isSubsetOf(other *listCore[T, C], mode Multiplicity) (bool, error) {
+	if l._ascending != other._ascending {
+		return false, errors.New("invalid ascending flag for given list")
+	}
+
+	for first, second := l.head, other.head; first != nil; {
+		var inFirst, inSecond int
+		value := l.nextRunValue(first, second)
+		first, inFirst = l.skipRun(first, value, mode)
+		second, inSecond = l.skipRun(second, value, mode)
+
+		if inFirst > inSecond {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (l *listCore[T, C]) combine(other *listCore[T, C], mode Multiplicity, copies func(inFirst, inSecond int) int) (*listCore[T, C], error) {
+	if l._ascending != other._ascending {
+		return nil, errors.New("invalid ascending flag for given list")
+	}
+
+	result := &listCore[T, C]{_ascending: l._ascending, cmp: l.cmp}
+
+	for first, second := l.head, other.head; first != nil || second != nil; {
+		var inFirst, inSecond int
+		value := l.nextRunValue(first, second)
+		first, inFirst = l.skipRun(first, value, mode)
+		second, inSecond = l.skipRun(second, value, mode)
+
+		for range copies(inFirst, inSecond) {
+			result.pushBack(value)
+		}
+	}
+
+	return result, nil
+}
+
+// value of the run which comes first in the list order, at least one of the nodes is not nil
+func (l *listCore[T, C]) nextRunValue(first, second *Node[T]) T {
+	if second == nil || (first != nil && l.isPivot(second, first.value, true)) {
+		return first.value
+	}
+	return second.value
+}
+
+// node after the run of the value and the length of the run, which is at most 1 for Distinct
+func (l *listCore[T, C]) skipRun(node *Node[T], value T, mode Multiplicity) (*Node[T], int) {
+	length := 0
+	for ; node != nil && l.equal(node.value, value); node = node.next {
+		length++
+	}
+
+	if mode == Distinct {
+		length = min(length, 1)
+	}
+
+	return node, length
+}
+
+// appends without searching, the caller guarantees that the value does not break the order
+func (l *listCore[T, C]) pushBack(value T) {
+	newNode := &Node[T]{value: value, prev: l.tail}
+
+	if l.tail == nil {
+		l.head = newNode
+	} else {
+		l.tail.next = newNode
+	}
+
+	l.tail = newNode
+	l.finger = newNode
+	l.count++
+	l.version++
+}
+
+/*
+* 7. Ordered list - k-way merge
+*
+* Merging k lists pairwise walks the already merged part again and again. Instead we keep one cursor per list in a binary heap,
+* ordered by the value of the cursor node in the list order, and always take the root. After taking it the cursor moves to the next
+* node of its list and sifts down, or the last cursor takes its place when the list is over - log(k) per node, so O(n log(k)) in
+* total. Equal values are ordered by the index of the list, this list first, so the merge is stable. Same as Merge the nodes are
+* relinked and not copied, so all lists share them afterwards and none of them may be given twice.
+*/
+
+type mergeCursor[T any] struct {
+	node *Node[T]
+	list int
+}
+
+type mergeHeap[T any] struct {
+	cursors []mergeCursor[T]
+	order   func(a, b T) int
+}
+
+func (l *listCore[T, C]) mergeAll(lists ...*listCore[T, C]) error {
+	for i, list := range lists {
+		if l._ascending != list._ascending {
+			return errors.New("invalid ascending flag for given list")
+		}
+
+		if l == list {
+			return errors.New("can not merge with itself")
+		}
+
+		if slices.Contains(lists[:i], list) {
+			return errors.New("list given more than once")
+		}
+	}
+
+	heap := mergeHeap[T]{order: l.order}
+	for i, list := range append([]*listCore[T, C]{l}, lists...) {
+		if list.head != nil {
+			heap.cursors = append(heap.cursors, mergeCursor[T]{node: list.head, list: i})
+		}
+	}
+	heap.init()
+
+	var merged Node[T]
+	last := &merged
+
+	for len(heap.cursors) > 0 {
+		top := &heap.cursors[0]
+		node := top.node
+		last.next, node.prev = node, last
+		last = node
+
+		if node.next != nil {
+			top.node = node.next
+		} else {
+			heap.cursors[0] = heap.cursors[len(heap.cursors)-1]
+			heap.cursors = heap.cursors[:len(heap.cursors)-1]
+		}
+		heap.siftDown(0)
+	}
+
+	for _, list := range lists {
+		l.count += list.count
+		list.version++
+	}
+
+	l.version++
+
+	if merged.next == nil {
+		return nil
+	}
+
+	l.head, l.tail = merged.next, last
+	l.head.prev = nil
+
+	return nil
+}
+
+func (h *mergeHeap[T]) init() {
+	for i := len(h.cursors)/2 - 1; i >= 0; i-- {
+		h.siftDown(i)
+	}
+}
+
+func (h *mergeHeap[T]) siftDown(i int) {
+	for {
+		smallest, left, right := i, 2*i+1, 2*i+2
+
+		if left < len(h.cursors) && h.before(left, smallest) {
+			smallest = left
+		}
+
+		if right < len(h.cursors) && h.before(right, smallest) {
+			smallest = right
+		}
+
+		if smallest == i {
+			return
+		}
+
+		h.cursors[i], h.cursors[smallest] = h.cursors[smallest], h.cursors[i]
+		i = smallest
+	}
+}
+
+func (h *mergeHeap[T]) before(i, j int) bool {
+	a, b := h.cursors[i], h.cursors[j]
+	order := h.order(a.node.value, b.node.value)
+
+	if order == 0 {
+		return a.list < b.list
+	}
+
+	return order < 0
+}
+
+/*
+* 7. Ordered list - bulk construction
+*
+* Adding n values one by one searches the place for each of them, so it is O(n^2) for random input. When the values are already
+* sorted there is nothing to search - every value goes to the tail, FromSorted only checks that the order is right and links the
+* nodes in O(n). FromUnsorted sorts a copy first, O(n log(n)), and then does the same. For streams which are only nearly sorted
+* there is the finger in Add - the search starts from the last inserted node, so the cost is the distance to it and not to the head.
+*/
+
+func FromSorted[T constraints.Ordered](asc bool, values ...T) (*OrderedList[T], error) {
+	l := NewOrderedList[T](asc)
+
+	if err := l.appendSorted(values); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func FromUnsorted[T constraints.Ordered](asc bool, values ...T) *OrderedList[T] {
+	l := NewOrderedList[T](asc)
+	l.appendUnsorted(values)
+	return l
+}
+
+func (l *listCore[T, C]) appendSorted(values []T) error {
+	for _, value := range values {
+		if l.tail != nil && !l.isPivot(l.tail, value, false) {
+			return errors.New("values are not sorted in the given order")
+		}
+		l.pushBack(value)
+	}
+
+	return nil
+}
+
+// the sort is stable, so values which the comparator treats as equal keep the given order
+func (l *listCore[T, C]) appendUnsorted(values []T) {
+	sorted := slices.Clone(values)
+	slices.SortStableFunc(sorted, l.order)
+	_ = l.appendSorted(sorted)
+}
+
+/*
+* 7. Ordered list - comparator variant
+*
+* constraints.Ordered means numbers and strings only, but more often we want to keep structs sorted - events by time and then by
+* id for example. Go has no operator overloading, so the order comes in as a function, same as in slices.SortFunc - negative
+* when a goes before b, zero when they are equal and positive otherwise. At first this was a copy of OrderedList with every <, <=
+* and == going through the comparator, and of course everything added to OrderedList later was missing here. Now both embed the
+* same listCore from solution.go with a different comparator, so ranges, set algebra, MergeAll and the finger work here as well.
+* Two values are equal when the comparator says so, even if the structs differ in some other field.
+*
+* Methods which only take and return values are promoted from listCore as they are. The ones which take or return another list
+* have to know the concrete type - an OrderedList must not be merged into an OrderedListFunc - so both types get a thin wrapper
+* for each of them below, the work itself is done once in listCore.
+*/
+
+type funcOrder[T any] func(a, b T) int
+
+func (f funcOrder[T]) compare(a, b T) int {
+	return f(a, b)
+}
+
+type OrderedListFunc[T any] struct {
+	listCore[T, funcOrder[T]]
+}
+
+func NewOrderedListFunc[T any](asc bool, cmp func(a, b T) int) *OrderedListFunc[T] {
+	l := &OrderedListFunc[T]{}
+	l._ascending = asc
+	l.cmp = cmp
+	return l
+}
+
+func FromSortedFunc[T any](asc bool, cmp func(a, b T) int, values ...T) (*OrderedListFunc[T], error) {
+	l := NewOrderedListFunc(asc, cmp)
+
+	if err := l.appendSorted(values); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func FromUnsortedFunc[T any](asc bool, cmp func(a, b T) int, values ...T) *OrderedListFunc[T] {
+	l := NewOrderedListFunc(asc, cmp)
+	l.appendUnsorted(values)
+	return l
+}
+
+func (l *OrderedList[T]) Merge(toMerge *OrderedList[T]) error {
+	return l.merge(&toMerge.listCore)
+}
+
+func (l *OrderedList[T]) MergeAll(lists ...*OrderedList[T]) error {
+	cores := make([]*listCore[T, naturalOrder[T]], len(lists))
+	for i, list := range lists {
+		cores[i] = &list.listCore
+	}
+	return l.mergeAll(cores...)
+}
+
+func (l *OrderedList[T]) ContainsSublist(subList *OrderedList[T]) (bool, error) {
+	return l.containsSublist(&subList.listCore)
+}
+
+func (l *OrderedList[T]) Union(other *OrderedList[T], mode Multiplicity) (*OrderedList[T], error) {
+	return wrapOrderedList(l.union(&other.listCore, mode))
+}
+
+func (l *OrderedList[T]) Intersection(other *OrderedList[T], mode Multiplicity) (*OrderedList[T], error) {
+	return wrapOrderedList(l.intersection(&other.listCore, mode))
+}
+
+func (l *OrderedList[T]) Difference(other *OrderedList[T], mode Multiplicity) (*OrderedList[T], error) {
+	return wrapOrderedList(l.difference(&other.listCore, mode))
+}
+
+func (l *OrderedList[T]) SymmetricDifference(other *OrderedList[T], mode Multiplicity) (*OrderedList[T], error) {
+	return wrapOrderedList(l.symmetricDifference(&other.listCore, mode))
+}
+
+func (l *OrderedList[T]) IsSubsetOf(other *OrderedList[T], mode Multiplicity) (bool, error) {
+	return l.isSubsetOf(&other.listCore, mode)
+}
+
+func wrapOrderedList[T constraints.Ordered](core *listCore[T, naturalOrder[T]], err error) (*OrderedList[T], error) {
+	if err != nil {
+		return nil, err
+	}
+	return &OrderedList[T]{listCore: *core}, nil
+}
+
+func (l *OrderedListFunc[T]) Merge(toMerge *OrderedListFunc[T]) error {
+	return l.merge(&toMerge.listCore)
+}
+
+func (l *OrderedListFunc[T]) MergeAll(lists ...*OrderedListFunc[T]) error {
+	cores := make([]*listCore[T, funcOrder[T]], len(lists))
+	for i, list := range lists {
+		cores[i] = &list.listCore
+	}
+	return l.mergeAll(cores...)
+}
+
+func (l *OrderedListFunc[T]) ContainsSublist(subList *OrderedListFunc[T]) (bool, error) {
+	return l.containsSublist(&subList.listCore)
+}
+
+func (l *OrderedListFunc[T]) Union(other *OrderedListFunc[T], mode Multiplicity) (*OrderedListFunc[T], error) {
+	return wrapOrderedListFunc(l.union(&other.listCore, mode))
+}
+
+func (l *OrderedListFunc[T]) Intersection(other *OrderedListFunc[T], mode Multiplicity) (*OrderedListFunc[T], error) {
+	return wrapOrderedListFunc(l.intersection(&other.listCore, mode))
+}
+
+func (l *OrderedListFunc[T]) Difference(other *OrderedListFunc[T], mode Multiplicity) (*OrderedListFunc[T], error) {
+	return wrapOrderedListFunc(l.difference(&other.listCore, mode))
+}
+
+func (l *OrderedListFunc[T]) SymmetricDifference(other *OrderedListFunc[T], mode Multiplicity) (*OrderedListFunc[T], error) {
+	return wrapOrderedListFunc(l.symmetricDifference(&other.listCore, mode))
+}
+
+func (l *OrderedListFunc[T]) IsSubsetOf(other *OrderedListFunc[T], mode Multiplicity) (bool, error) {
+	return l.isSubsetOf(&other.listCore, mode)
+}
+
+func wrapOrderedListFunc[T any](core *listCore[T, funcOrder[T]], err error) (*OrderedListFunc[T], error) {
+	if err != nil {
+		return nil, err
+	}
+	return &OrderedListFunc[T]{listCore: *core}, nil
+}
+
 /*
 * 7. Ordered list - task number 12 - O(log(n)) search
 *
@@ -183,10 +731,11 @@ func (l *OrderedList[T]) MostFrequent() (T, error) {
 */
 
 type BSTNode[T constraints.Ordered] struct {
-	value T
-	left  *BSTNode[T]
-	right *BSTNode[T]
-	size  int
+	value  T
+	left   *BSTNode[T]
+	right  *BSTNode[T]
+	size   int
+	height int
 }
 
 type OrderedListBST[T constraints.Ordered] struct {
@@ -220,4 +769,606 @@ func (l *OrderedListBST[T]) getByIndexRec(node *BSTNode[T], index int) T {
 	}
 
 	return l.getByIndexRec(node.right, index - leftSize - 1)
-}
\ No newline at end of file
+}
+
+/*
+* 7. Ordered list - task number 12 continuation - the rest of the list on top of the BST
+*
+* GetByIndex alone was not enough to use the tree as a list, so here comes the rest. To keep everything O(log(n)) the tree has to
+* stay balanced, I took AVL - every node knows its height and after each insertion or deletion on the way back up we rotate where
+* the heights of the subtrees differ by more than one. Rotation only relinks three pointers, so the sizes are recomputed from the
+* children for the two nodes that moved, same as the heights. The tree is ordered by isBefore, which looks at the ascending flag,
+* so the in order traversal is always the list order and GetByIndex does not care about the direction. Equal values go to the right
+* on insertion, FindPosition however searches for the first position which is not before the value, so with duplicates we still
+* get the first occurrence like in OrderedList.
+*/
+
+func (l *OrderedListBST[T]) Count() int {
+	return nodeSize(l.root)
+}
+
+func (l *OrderedListBST[T]) Clear(asc bool) {
+	l.root = nil
+	l.ascending = asc
+}
+
+func (l *OrderedListBST[T]) Add(item T) {
+	l.root = l.addRec(l.root, item)
+}
+
+func (l *OrderedListBST[T]) addRec(node *BSTNode[T], item T) *BSTNode[T] {
+	if node == nil {
+		return &BSTNode[T]{value: item, size: 1, height: 1}
+	}
+
+	if l.isBefore(item, node.value) {
+		node.left = l.addRec(node.left, item)
+	} else {
+		node.right = l.addRec(node.right, item)
+	}
+
+	return rebalance(node)
+}
+
+// removes one occurrence of the value if there is any
+func (l *OrderedListBST[T]) Delete(n T) {
+	l.root = l.deleteRec(l.root, n)
+}
+
+func (l *OrderedListBST[T]) deleteRec(node *BSTNode[T], n T) *BSTNode[T] {
+	if node == nil {
+		return nil
+	}
+
+	if l.isBefore(n, node.value) {
+		node.left = l.deleteRec(node.left, n)
+		return rebalance(node)
+	}
+
+	if l.isBefore(node.value, n) {
+		node.right = l.deleteRec(node.right, n)
+		return rebalance(node)
+	}
+
+	if node.left == nil {
+		return node.right
+	}
+
+	if node.right == nil {
+		return node.left
+	}
+
+	successor := node.right
+	for ; successor.left != nil; successor = successor.left {}
+
+	node.value = successor.value
+	node.right = deleteMin(node.right)
+	return rebalance(node)
+}
+
+func (l *OrderedListBST[T]) Find(n T) (BSTNode[T], error) {
+	var result BSTNode[T]
+
+	if l.root == nil {
+		return result, errors.New("empty list")
+	}
+
+	for node := l.root; node != nil; {
+		if node.value == n {
+			return *node, nil
+		}
+
+		if l.isBefore(n, node.value) {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+
+	return result, errors.New("not found")
+}
+
+func (l *OrderedListBST[T]) FindPosition(value T) (int, bool) {
+	position := 0
+	var candidate *BSTNode[T]
+
+	for node := l.root; node != nil; {
+		if l.isBefore(node.value, value) {
+			position += nodeSize(node.left) + 1
+			node = node.right
+		} else {
+			candidate = node
+			node = node.left
+		}
+	}
+
+	return position, candidate != nil && candidate.value == value
+}
+
+func (l *OrderedListBST[T]) isBefore(a T, b T) bool {
+	if l.ascending {
+		return a < b
+	}
+	return a > b
+}
+
+func deleteMin[T constraints.Ordered](node *BSTNode[T]) *BSTNode[T] {
+	if node.left == nil {
+		return node.right
+	}
+
+	node.left = deleteMin(node.left)
+	return rebalance(node)
+}
+
+func rebalance[T constraints.Ordered](node *BSTNode[T]) *BSTNode[T] {
+	updateNode(node)
+	balance := nodeHeight(node.left) - nodeHeight(node.right)
+
+	if balance > 1 {
+		if nodeHeight(node.left.left) < nodeHeight(node.left.right) {
+			node.left = rotateLeft(node.left)
+		}
+		return rotateRight(node)
+	}
+
+	if balance < -1 {
+		if nodeHeight(node.right.right) < nodeHeight(node.right.left) {
+			node.right = rotateRight(node.right)
+		}
+		return rotateLeft(node)
+	}
+
+	return node
+}
+
+func rotateLeft[T constraints.Ordered](node *BSTNode[T]) *BSTNode[T] {
+	newRoot := node.right
+	node.right = newRoot.left
+	newRoot.left = node
+	updateNode(node)
+	updateNode(newRoot)
+	return newRoot
+}
+
+func rotateRight[T constraints.Ordered](node *BSTNode[T]) *BSTNode[T] {
+	newRoot := node.left
+	node.left = newRoot.right
+	newRoot.right = node
+	updateNode(node)
+	updateNode(newRoot)
+	return newRoot
+}
+
+func updateNode[T constraints.Ordered](node *BSTNode[T]) {
+	node.size = nodeSize(node.left) + nodeSize(node.right) + 1
+	node.height = max(nodeHeight(node.left), nodeHeight(node.right)) + 1
+}
+
+func nodeSize[T constraints.Ordered](node *BSTNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+func nodeHeight[T constraints.Ordered](node *BSTNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+/*
+* 7. Ordered list - skip list
+*
+* Another structure which gives O(log(n)) search, this time probabilistic. Every node gets a random number of levels, each level
+* is a linked list which skips over the nodes with less levels, so on average every level is a quarter of the one below and the
+* search goes down like in a binary search. To also answer at which position a value is, every link stores its width - how many
+* nodes of the bottom level it jumps over. Summing widths on the way down gives the position, the same trick as the size in
+* BSTNode. The link to the end of a level has the width as if there was one more node after the tail, this way insertion and
+* deletion update all the widths with the same formula. The bottom level is also linked backwards for Backward.
+*
+* The list has the same direction flag as OrderedList and all comparisons go through isBefore. The levels come from the random
+* source given to the constructor, so with a seeded one the shape of the list is the same in every run.
+*/
+
+const (
+	skipListMaxLevel    = 32
+	skipListProbability = 0.25
+)
+
+type SkipNode[T constraints.Ordered] struct {
+	value T
+	next  []*SkipNode[T]
+	width []int
+	prev  *SkipNode[T]
+}
+
+type SkipList[T constraints.Ordered] struct {
+	head       *SkipNode[T]
+	tail       *SkipNode[T]
+	level      int
+	count      int
+	_ascending bool
+	random     *rand.Rand
+	version    int
+}
+
+func NewSkipList[T constraints.Ordered](asc bool) *SkipList[T] {
+	return NewSkipListRandom[T](asc, rand.New(rand.NewSource(time.Now().UnixNano())))
+}
+
+func NewSkipListRandom[T constraints.Ordered](asc bool, random *rand.Rand) *SkipList[T] {
+	l := &SkipList[T]{random: random}
+	l.Clear(asc)
+	return l
+}
+
+func (l *SkipList[T]) Count() int {
+	return l.count
+}
+
+func (l *SkipList[T]) Clear(asc bool) {
+	l.head = &SkipNode[T]{next: make([]*SkipNode[T], skipListMaxLevel), width: make([]int, skipListMaxLevel)}
+	for i := range l.head.width {
+		l.head.width[i] = 1
+	}
+	l.tail = nil
+	l.level = 1
+	l.count = 0
+	l._ascending = asc
+	l.version++
+}
+
+// the new value goes before the values equal to it, same as in OrderedList
+func (l *SkipList[T]) Add(item T) {
+	update, rank := l.findPredecessors(item)
+	level := l.randomLevel()
+
+	for ; l.level < level; l.level++ {
+		update[l.level] = l.head
+		rank[l.level] = 0
+		l.head.width[l.level] = l.count + 1
+	}
+
+	newNode := &SkipNode[T]{value: item, next: make([]*SkipNode[T], level), width: make([]int, level)}
+
+	for i := 0; i < level; i++ {
+		newNode.next[i] = update[i].next[i]
+		update[i].next[i] = newNode
+		newNode.width[i] = update[i].width[i] - (rank[0] - rank[i])
+		update[i].width[i] = rank[0] - rank[i] + 1
+	}
+
+	for i := level; i < l.level; i++ {
+		update[i].width[i]++
+	}
+
+	if update[0] != l.head {
+		newNode.prev = update[0]
+	}
+
+	if newNode.next[0] != nil {
+		newNode.next[0].prev = newNode
+	} else {
+		l.tail = newNode
+	}
+
+	l.count++
+	l.version++
+}
+
+func (l *SkipList[T]) Find(n T) (SkipNode[T], error) {
+	var result SkipNode[T]
+
+	if l.count == 0 {
+		return result, errors.New("empty list")
+	}
+
+	update, _ := l.findPredecessors(n)
+	candidate := update[0].next[0]
+
+	if candidate == nil || candidate.value != n {
+		return result, errors.New("not found")
+	}
+
+	return *candidate, nil
+}
+
+// removes the first occurrence of the value if there is any
+func (l *SkipList[T]) Delete(n T) {
+	update, _ := l.findPredecessors(n)
+	toDelete := update[0].next[0]
+
+	if toDelete == nil || toDelete.value != n {
+		return
+	}
+
+	for i := 0; i < l.level; i++ {
+		if update[i].next[i] == toDelete {
+			update[i].width[i] += toDelete.width[i] - 1
+			update[i].next[i] = toDelete.next[i]
+		} else {
+			update[i].width[i]--
+		}
+	}
+
+	if toDelete.next[0] != nil {
+		toDelete.next[0].prev = toDelete.prev
+	} else {
+		l.tail = toDelete.prev
+	}
+
+	for l.level > 1 && l.head.next[l.level-1] == nil {
+		l.level--
+	}
+
+	l.count--
+	l.version++
+}
+
+// same contract as OrderedList.FindPosition - position of the first occurrence or where the value would be inserted
+func (l *SkipList[T]) FindPosition(value T) (int, bool) {
+	update, rank := l.findPredecessors(value)
+	candidate := update[0].next[0]
+	return rank[0], candidate != nil && candidate.value == value
+}
+
+func (l *SkipList[T]) GetByIndex(index int) (T, error) {
+	var zero T
+
+	if index < 0 || index >= l.count {
+		return zero, errors.New("index out of range")
+	}
+
+	return l.nodeAt(index).value, nil
+}
+
+func (l *SkipList[T]) All() iter.Seq[T] {
+	return l.AllFrom(0)
+}
+
+// values starting with the one at the given position, the start is found through the widths in O(log(n))
+func (l *SkipList[T]) AllFrom(position int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// clamped first, nodeAt(0) on an empty list would be the head sentinel
+		position = max(position, 0)
+		if position >= l.count {
+			return
+		}
+
+		version := l.version
+		for temp := l.nodeAt(position); temp != nil; temp = temp.next[0] {
+			if !yield(temp.value) {
+				return
+			}
+			l.checkVersion(version)
+		}
+	}
+}
+
+// node with the given index, which has to be in range
+func (l *SkipList[T]) nodeAt(index int) *SkipNode[T] {
+	current, position := l.head, 0
+
+	for i := l.level - 1; i >= 0; i-- {
+		for current.next[i] != nil && position+current.width[i] <= index+1 {
+			position += current.width[i]
+			current = current.next[i]
+		}
+	}
+
+	return current
+}
+
+func (l *SkipList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		version := l.version
+		for temp := l.tail; temp != nil; temp = temp.prev {
+			if !yield(temp.value) {
+				return
+			}
+			l.checkVersion(version)
+		}
+	}
+}
+
+func (l *SkipList[T]) Compare(v1 T, v2 T) int {
+	if v1 < v2 {
+		return -1
+	}
+	if v1 > v2 {
+		return +1
+	}
+	return 0
+}
+
+/*
+* The tasks 8, 9 and 11 for the skip list. Removing or merging node by node would need the predecessors on every level for every
+* node, so both collect the values in the list order and build the list again. Building from already ordered values is linear -
+* every new node goes to the end, so we only remember the last node of each level and its position, no search needed.
+*/
+
+func (l *SkipList[T]) RemoveDuplicates() {
+	if l.count < 2 {
+		return
+	}
+
+	values := make([]T, 0, l.count)
+	for temp := l.head.next[0]; temp != nil; temp = temp.next[0] {
+		if len(values) == 0 || values[len(values)-1] != temp.value {
+			values = append(values, temp.value)
+		}
+	}
+
+	l.rebuild(values)
+}
+
+// unlike OrderedList.Merge the nodes are not shared, toMerge stays as it was
+func (l *SkipList[T]) Merge(toMerge *SkipList[T]) error {
+	if l._ascending != toMerge._ascending {
+		return errors.New("invalid ascending flag for given list")
+	}
+
+	if l == toMerge {
+		return errors.New("can not merge with itself")
+	}
+
+	values := make([]T, 0, l.count+toMerge.count)
+	first, second := l.head.next[0], toMerge.head.next[0]
+
+	for first != nil || second != nil {
+		if second == nil || (first != nil && !l.isBefore(second.value, first.value)) {
+			values = append(values, first.value)
+			first = first.next[0]
+		} else {
+			values = append(values, second.value)
+			second = second.next[0]
+		}
+	}
+
+	l.rebuild(values)
+	return nil
+}
+
+// the same contract and errors as OrderedList.ContainsSublist. The first value of the sublist is found in O(log(n)) and if the
+// list has more copies of it than the sublist starts with, the sublist can only start at the last of them - after the run
+// the list continues with another value
+func (l *SkipList[T]) ContainsSublist(subList *SkipList[T]) (bool, error) {
+	if l.count == 0 {
+		return false, errors.New("empty list")
+	}
+
+	if l._ascending != subList._ascending {
+		return false, errors.New("invalid ascending flag for given list")
+	}
+
+	if subList.count == 0 {
+		return true, nil
+	}
+
+	first := subList.head.next[0]
+	update, _ := l.findPredecessors(first.value)
+	start := update[0].next[0]
+	leading, run := runLength(first), runLength(start)
+
+	if run < leading {
+		return false, nil
+	}
+
+	for i := 0; i < run-leading; i++ {
+		start = start.next[0]
+	}
+
+	for sub := first; sub != nil; sub = sub.next[0] {
+		if start == nil || start.value != sub.value {
+			return false, nil
+		}
+		start = start.next[0]
+	}
+
+	return true, nil
+}
+
+func (l *SkipList[T]) MostFrequent() (T, error) {
+	var result T
+
+	if l.count == 0 {
+		return result, errors.New("empty list")
+	}
+
+	maxWindow := 0
+
+	for temp := l.head.next[0]; temp != nil; {
+		window, end := 1, temp.next[0]
+		for ; end != nil && end.value == temp.value; end = end.next[0] {
+			window++
+		}
+		if window > maxWindow {
+			result = temp.value
+			maxWindow = window
+		}
+		temp = end
+	}
+
+	return result, nil
+}
+
+func (l *SkipList[T]) rebuild(values []T) {
+	l.Clear(l._ascending)
+	last := make([]*SkipNode[T], skipListMaxLevel)
+	lastPosition := make([]int, skipListMaxLevel)
+
+	for i := range last {
+		last[i] = l.head
+	}
+
+	for position, value := range values {
+		level := l.randomLevel()
+		newNode := &SkipNode[T]{value: value, next: make([]*SkipNode[T], level), width: make([]int, level)}
+
+		for i := 0; i < level; i++ {
+			last[i].next[i] = newNode
+			last[i].width[i] = position + 1 - lastPosition[i]
+			last[i], lastPosition[i] = newNode, position+1
+		}
+
+		newNode.prev = l.tail
+		l.tail = newNode
+		l.level = max(l.level, level)
+	}
+
+	for i := 0; i < l.level; i++ {
+		last[i].width[i] = len(values) + 1 - lastPosition[i]
+	}
+
+	l.count = len(values)
+}
+
+// number of nodes from the given one on with the same value, 0 for nil
+func runLength[T constraints.Ordered](node *SkipNode[T]) int {
+	length := 0
+	for temp := node; temp != nil && temp.value == node.value; temp = temp.next[0] {
+		length++
+	}
+	return length
+}
+
+func (l *SkipList[T]) checkVersion(version int) {
+	if l.version != version {
+		panic(errModifiedDuringIteration)
+	}
+}
+
+func (l *SkipList[T]) isBefore(a T, b T) bool {
+	if l._ascending {
+		return a < b
+	}
+	return a > b
+}
+
+// for every level the last node before the value and its position, where the head has position 0
+func (l *SkipList[T]) findPredecessors(value T) ([]*SkipNode[T], []int) {
+	update := make([]*SkipNode[T], skipListMaxLevel)
+	rank := make([]int, skipListMaxLevel)
+	current, position := l.head, 0
+
+	for i := l.level - 1; i >= 0; i-- {
+		for current.next[i] != nil && l.isBefore(current.next[i].value, value) {
+			position += current.width[i]
+			current = current.next[i]
+		}
+		update[i] = current
+		rank[i] = position
+	}
+
+	return update, rank
+}
+
+func (l *SkipList[T]) randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && l.random.Float64() < skipListProbability {
+		level++
+	}
+	return level
+}
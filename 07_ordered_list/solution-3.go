@@ -1,6 +1,9 @@
 package ordered_list
 
 import (
+	"cmp"
+	"math/rand"
+	"slices"
 	"testing"
 	"constraints"
 	"github.com/stretchr/testify/assert"
@@ -9,7 +12,7 @@ import (
 // helpers
 
 func makeAscList(values ...int) OrderedList[int] {
-	list := OrderedList[int]{_ascending: true}
+	list := *NewOrderedList[int](true)
 	for _, v := range values {
 		list.Add(v)
 	}
@@ -17,7 +20,7 @@ func makeAscList(values ...int) OrderedList[int] {
 }
 
 func makeDescList(values ...int) OrderedList[int] {
-	list := OrderedList[int]{_ascending: false}
+	list := *NewOrderedList[int](false)
 	for _, v := range values {
 		list.Add(v)
 	}
@@ -36,7 +39,7 @@ func toSlice[T constraints.Ordered](list *OrderedList[T]) []T {
 
 func Test_GivenEmptyList_WhenGettingCount_ThenReturnsZero(t *testing.T) {
 	// Given
-	list := OrderedList[int]{_ascending: true}
+	list := makeAscList()
 
 	// When
 	count := list.Count()
@@ -60,7 +63,7 @@ func Test_GivenListWithElements_WhenGettingCount_ThenReturnsCorrectNumber(t *tes
 
 func Test_GivenEmptyAscendingList_WhenAddingElement_ThenBecomesHeadAndTail(t *testing.T) {
 	// Given
-	list := OrderedList[int]{_ascending: true}
+	list := makeAscList()
 
 	// When
 	list.Add(5)
@@ -109,7 +112,7 @@ func Test_GivenAscendingList_WhenAddingMiddleElement_ThenInsertsInCorrectPositio
 
 func Test_GivenAscendingList_WhenAddingMultipleElements_ThenMaintainsOrder(t *testing.T) {
 	// Given
-	list := OrderedList[int]{_ascending: true}
+	list := makeAscList()
 
 	// When - add in random order
 	list.Add(8)
@@ -139,7 +142,7 @@ func Test_GivenAscendingList_WhenAddingDuplicates_ThenMaintainsAllCopies(t *test
 
 func Test_GivenEmptyDescendingList_WhenAddingElement_ThenBecomesHeadAndTail(t *testing.T) {
 	// Given
-	list := OrderedList[int]{_ascending: false}
+	list := makeDescList()
 
 	// When
 	list.Add(5)
@@ -186,7 +189,7 @@ func Test_GivenDescendingList_WhenAddingMiddleElement_ThenInsertsInCorrectPositi
 
 func Test_GivenDescendingList_WhenAddingMultipleElements_ThenMaintainsOrder(t *testing.T) {
 	// Given
-	list := OrderedList[int]{_ascending: false}
+	list := makeDescList()
 
 	// When - add in random order
 	list.Add(3)
@@ -203,7 +206,7 @@ func Test_GivenDescendingList_WhenAddingMultipleElements_ThenMaintainsOrder(t *t
 
 func Test_GivenEmptyList_WhenFinding_ThenReturnsError(t *testing.T) {
 	// Given
-	list := OrderedList[int]{_ascending: true}
+	list := makeAscList()
 
 	// When
 	_, err := list.Find(5)
@@ -309,7 +312,7 @@ func Test_GivenDescendingList_WhenFindingValueLargerThanAll_ThenReturnsErrorEarl
 
 func Test_GivenEmptyList_WhenDeleting_ThenNothingHappens(t *testing.T) {
 	// Given
-	list := OrderedList[int]{_ascending: true}
+	list := makeAscList()
 
 	// When
 	list.Delete(5)
@@ -448,7 +451,7 @@ func Test_GivenAscendingList_WhenClearingWithDescending_ThenChangesOrder(t *test
 
 func Test_GivenTwoIntegers_WhenComparing_ThenReturnsCorrectResult(t *testing.T) {
 	// Given
-	list := OrderedList[int]{_ascending: true}
+	list := makeAscList()
 
 	// When/Then
 	assert.Equal(t, -1, list.Compare(5, 10))
@@ -460,7 +463,7 @@ func Test_GivenTwoIntegers_WhenComparing_ThenReturnsCorrectResult(t *testing.T)
 
 func Test_GivenList_WhenAlternatingAddAndDelete_ThenMaintainsOrder(t *testing.T) {
 	// Given
-	list := OrderedList[int]{_ascending: true}
+	list := makeAscList()
 
 	// When
 	list.Add(10)
@@ -477,7 +480,7 @@ func Test_GivenList_WhenAlternatingAddAndDelete_ThenMaintainsOrder(t *testing.T)
 
 func Test_GivenDescendingList_WhenMultipleOperations_ThenMaintainsOrder(t *testing.T) {
 	// Given
-	list := OrderedList[int]{_ascending: false}
+	list := makeDescList()
 
 	// When
 	list.Add(5)
@@ -495,7 +498,7 @@ func Test_GivenDescendingList_WhenMultipleOperations_ThenMaintainsOrder(t *testi
 
 func Test_GivenEmptyList_WhenRemovingDuplicates_ThenRemainsEmpty(t *testing.T) {
 	// Given
-	list := OrderedList[int]{_ascending: true}
+	list := makeAscList()
 
 	// When
 	list.RemoveDuplicates()
@@ -591,12 +594,29 @@ func Test_GivenListWithConsecutiveDuplicates_WhenRemovingDuplicates_ThenRemovesA
 	assert.Equal(t, 5, list.Count())
 }
 
+func Test_GivenListEndingWithDuplicates_WhenRemovingDuplicatesThenRangingBackwardAndAdding_ThenTailIsLastKeptNode(t *testing.T) {
+	// Given
+	list := makeAscList(1, 2, 2)
+
+	// When
+	list.RemoveDuplicates()
+	backward := slices.Collect(list.Backward())
+	list.Add(3)
+
+	// Then
+	assert.Equal(t, []int{2, 1}, backward)
+	assert.Nil(t, list.tail.next)
+	assert.Equal(t, []int{1, 2, 3}, toSlice(&list))
+	assert.Equal(t, []int{3, 2, 1}, slices.Collect(list.Backward()))
+	assert.Equal(t, 3, list.Count())
+}
+
 // TASK 9: MERGE TWO ORDERED LISTS
 
 func Test_GivenTwoEmptyLists_WhenMerging_ThenResultIsEmpty(t *testing.T) {
 	// Given
-	list1 := OrderedList[int]{_ascending: true}
-	list2 := OrderedList[int]{_ascending: true}
+	list1 := makeAscList()
+	list2 := makeAscList()
 
 	// When
 	err := list1.Merge(&list2)
@@ -608,7 +628,7 @@ func Test_GivenTwoEmptyLists_WhenMerging_ThenResultIsEmpty(t *testing.T) {
 
 func Test_GivenEmptyListAndNonEmpty_WhenMerging_ThenReturnsNonEmpty(t *testing.T) {
 	// Given
-	list1 := OrderedList[int]{_ascending: true}
+	list1 := makeAscList()
 	list2 := makeAscList(1, 2, 3)
 
 	// When
@@ -622,7 +642,7 @@ func Test_GivenEmptyListAndNonEmpty_WhenMerging_ThenReturnsNonEmpty(t *testing.T
 func Test_GivenNonEmptyAndEmpty_WhenMerging_ThenReturnsNonEmpty(t *testing.T) {
 	// Given
 	list1 := makeAscList(1, 2, 3)
-	list2 := OrderedList[int]{_ascending: true}
+	list2 := makeAscList()
 
 	// When
 	err := list1.Merge(&list2)
@@ -742,11 +762,11 @@ func Test_GivenListsWithDifferentOrder_WhenMerging_ThenReturnsError(t *testing.T
 
 func Test_GivenAscendingAndDescending_WhenMerging_ThenReturnsError(t *testing.T) {
 	// Given
-	list1 := OrderedList[int]{_ascending: true}
+	list1 := makeAscList()
 	list1.Add(5)
 	list1.Add(10)
 
-	list2 := OrderedList[int]{_ascending: false}
+	list2 := makeDescList()
 	list2.Add(20)
 	list2.Add(15)
 
@@ -760,8 +780,8 @@ func Test_GivenAscendingAndDescending_WhenMerging_ThenReturnsError(t *testing.T)
 
 func Test_GivenEmptyListsWithDifferentOrder_WhenMerging_ThenReturnsError(t *testing.T) {
 	// Given
-	list1 := OrderedList[int]{_ascending: true}
-	list2 := OrderedList[int]{_ascending: false}
+	list1 := makeAscList()
+	list2 := makeDescList()
 
 	// When
 	err := list1.Merge(&list2)
@@ -812,8 +832,8 @@ func Test_GivenDescendingListsDisjoint_WhenMerging_ThenMergesCorrectly(t *testin
 
 func Test_GivenEmptyList_WhenCheckingEmptySublist_ThenReturnsError(t *testing.T) {
 	// Given
-	list := OrderedList[int]{_ascending: true}
-	sublist := OrderedList[int]{_ascending: true}
+	list := makeAscList()
+	sublist := makeAscList()
 
 	// When
 	contains, err := list.ContainsSublist(&sublist)
@@ -826,7 +846,7 @@ func Test_GivenEmptyList_WhenCheckingEmptySublist_ThenReturnsError(t *testing.T)
 
 func Test_GivenEmptyList_WhenCheckingNonEmptySublist_ThenReturnsError(t *testing.T) {
 	// Given
-	list := OrderedList[int]{_ascending: true}
+	list := makeAscList()
 	sublist := makeAscList(1, 2)
 
 	// When
@@ -840,7 +860,7 @@ func Test_GivenEmptyList_WhenCheckingNonEmptySublist_ThenReturnsError(t *testing
 func Test_GivenNonEmptyList_WhenCheckingEmptySublist_ThenReturnsTrue(t *testing.T) {
 	// Given
 	list := makeAscList(1, 2, 3)
-	sublist := OrderedList[int]{_ascending: true}
+	sublist := makeAscList()
 
 	// When
 	contains, err := list.ContainsSublist(&sublist)
@@ -995,8 +1015,8 @@ func Test_GivenDescendingListAndAscendingSublist_WhenChecking_ThenReturnsError(t
 
 func Test_GivenEmptyListWithDifferentOrder_WhenCheckingSublist_ThenReturnsEmptyListError(t *testing.T) {
 	// Given
-	list := OrderedList[int]{_ascending: true}
-	sublist := OrderedList[int]{_ascending: false}
+	list := makeAscList()
+	sublist := makeDescList()
 
 	// When
 	contains, err := list.ContainsSublist(&sublist)
@@ -1045,6 +1065,19 @@ func Test_GivenSublistInMiddleWithDuplicates_WhenChecking_ThenReturnsTrue(t *tes
 	assert.True(t, contains)
 }
 
+func Test_GivenListWithLongerRunThanSublistStartsWith_WhenChecking_ThenReturnsTrue(t *testing.T) {
+	// Given
+	list := makeAscList(1, 2, 2, 2, 3, 4)
+	sublist := makeAscList(2, 3)
+
+	// When
+	contains, err := list.ContainsSublist(&sublist)
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, contains)
+}
+
 func Test_GivenSublistWithMoreDuplicatesThanList_WhenChecking_ThenReturnsFalse(t *testing.T) {
 	// Given
 	list := makeAscList(1, 2, 3, 3, 4, 5)
@@ -1088,7 +1121,7 @@ func Test_GivenPartialMatchAtEnd_WhenChecking_ThenReturnsFalse(t *testing.T) {
 
 func Test_GivenEmptyList_WhenFindingMostFrequent_ThenReturnsError(t *testing.T) {
 	// Given
-	list := OrderedList[int]{_ascending: true}
+	list := makeAscList()
 
 	// When
 	_, err := list.MostFrequent()
@@ -1215,4 +1248,1581 @@ func Test_GivenListWithTwoElementsSameFrequency_WhenFinding_ThenReturnsFirst(t *
 	// Then
 	assert.NoError(t, err)
 	assert.Equal(t, 1, value)
-}
\ No newline at end of file
+}
+
+// ALL / BACKWARD
+
+func Test_GivenAscendingList_WhenRangingOverAll_ThenValuesAscending(t *testing.T) {
+	// Given
+	list := makeAscList(3, 1, 2)
+
+	// When
+	values := slices.Collect(list.All())
+
+	// Then
+	assert.Equal(t, []int{1, 2, 3}, values)
+	assert.Equal(t, 3, list.Count())
+}
+
+func Test_GivenDescendingList_WhenRangingOverAll_ThenValuesDescending(t *testing.T) {
+	// Given
+	list := makeDescList(3, 1, 2)
+
+	// When
+	values := slices.Collect(list.All())
+
+	// Then
+	assert.Equal(t, []int{3, 2, 1}, values)
+}
+
+func Test_GivenAscendingList_WhenRangingOverBackward_ThenValuesDescending(t *testing.T) {
+	// Given
+	list := makeAscList(3, 1, 2)
+
+	// When
+	values := slices.Collect(list.Backward())
+
+	// Then
+	assert.Equal(t, []int{3, 2, 1}, values)
+	assert.Equal(t, []int{1, 2, 3}, toSlice(&list))
+}
+
+// MODIFICATION DURING ITERATION
+
+func assertPanicsWhenModifiedDuringAll(t *testing.T, list *OrderedList[int], mutate func(*OrderedList[int])) {
+	assert.PanicsWithError(t, errModifiedDuringIteration.Error(), func() {
+		for range list.All() {
+			mutate(list)
+		}
+	})
+}
+
+func Test_GivenIteration_WhenAdding_ThenPanics(t *testing.T) {
+	// Given
+	list := makeAscList(1, 2, 3)
+
+	// When/Then
+	assertPanicsWhenModifiedDuringAll(t, &list, func(l *OrderedList[int]) { l.Add(4) })
+}
+
+func Test_GivenIteration_WhenDeletingExistingValue_ThenPanics(t *testing.T) {
+	// Given
+	list := makeAscList(1, 2, 3)
+
+	// When/Then
+	assertPanicsWhenModifiedDuringAll(t, &list, func(l *OrderedList[int]) { l.Delete(2) })
+}
+
+func Test_GivenIteration_WhenDeletingMissingValue_ThenIterationCompletes(t *testing.T) {
+	// Given
+	list := makeAscList(1, 3, 5)
+	var visited []int
+
+	// When
+	for v := range list.All() {
+		list.Delete(4)
+		list.Delete(99)
+		visited = append(visited, v)
+	}
+
+	// Then
+	assert.Equal(t, []int{1, 3, 5}, visited)
+}
+
+func Test_GivenIteration_WhenClearing_ThenPanics(t *testing.T) {
+	// Given
+	list := makeAscList(1, 2, 3)
+
+	// When/Then
+	assertPanicsWhenModifiedDuringAll(t, &list, func(l *OrderedList[int]) { l.Clear(true) })
+}
+
+func Test_GivenIteration_WhenRemovingDuplicates_ThenPanics(t *testing.T) {
+	// Given
+	list := makeAscList(1, 1, 2)
+
+	// When/Then
+	assertPanicsWhenModifiedDuringAll(t, &list, func(l *OrderedList[int]) { l.RemoveDuplicates() })
+}
+
+func Test_GivenIteration_WhenMergingIntoList_ThenPanics(t *testing.T) {
+	// Given
+	list := makeAscList(1, 2, 3)
+
+	// When/Then
+	assertPanicsWhenModifiedDuringAll(t, &list, func(l *OrderedList[int]) {
+		other := makeAscList(4)
+		_ = l.Merge(&other)
+	})
+}
+
+func Test_GivenIterationOverMergedList_WhenMergeConsumesIt_ThenPanics(t *testing.T) {
+	// Given
+	list := makeAscList(1, 2, 3)
+	other := makeAscList(4, 5)
+
+	// When/Then
+	assertPanicsWhenModifiedDuringAll(t, &other, func(o *OrderedList[int]) { _ = list.Merge(o) })
+}
+
+func Test_GivenIterationOverEmptyList_WhenMergedInto_ThenVersionNotTakenFromOtherList(t *testing.T) {
+	// Given
+	list := makeAscList()
+	other := makeAscList(1, 2)
+
+	// When
+	_ = list.Merge(&other)
+
+	// Then
+	assert.NotEqual(t, other.version, list.version)
+	assert.Equal(t, []int{1, 2}, slices.Collect(list.All()))
+}
+
+func Test_GivenBackwardIteration_WhenAdding_ThenPanics(t *testing.T) {
+	// Given
+	list := makeDescList(1, 2, 3)
+
+	// When/Then
+	assert.PanicsWithError(t, errModifiedDuringIteration.Error(), func() {
+		for range list.Backward() {
+			list.Add(0)
+		}
+	})
+}
+
+// SKIP LIST
+
+func newSeededSkipList(asc bool) *SkipList[int] {
+	return NewSkipListRandom[int](asc, rand.New(rand.NewSource(1)))
+}
+
+func makeSkipList(values ...int) *SkipList[int] {
+	list := newSeededSkipList(true)
+	for _, v := range values {
+		list.Add(v)
+	}
+	return list
+}
+
+func Test_GivenValuesInAnyOrder_WhenAddingToSkipList_ThenAllAscending(t *testing.T) {
+	// Given
+	list := newSeededSkipList(true)
+
+	// When
+	for i := 0; i < 500; i++ {
+		list.Add((i * 7919) % 500)
+	}
+
+	// Then
+	values := slices.Collect(list.All())
+	assert.Len(t, values, 500)
+	assert.True(t, slices.IsSorted(values))
+	assert.Equal(t, 500, list.Count())
+}
+
+func Test_GivenSkipList_WhenRangingOverBackward_ThenValuesDescending(t *testing.T) {
+	// Given
+	list := makeSkipList(3, 1, 2, 5, 4)
+
+	// When
+	values := slices.Collect(list.Backward())
+
+	// Then
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, values)
+}
+
+func Test_GivenSkipList_WhenFindingPosition_ThenSameAsOrderedList(t *testing.T) {
+	// Given
+	values := []int{40, 10, 30, 10, 20, 50, 30}
+	skipList := makeSkipList(values...)
+	list := makeAscList(values...)
+
+	for _, v := range []int{0, 10, 15, 20, 30, 35, 50, 60} {
+		// When
+		skipPosition, skipFound := skipList.FindPosition(v)
+
+		// Then
+		listPosition, listFound := list.FindPosition(v)
+		assert.Equal(t, listPosition, skipPosition, "position of %d", v)
+		assert.Equal(t, listFound, skipFound, "found %d", v)
+	}
+}
+
+func Test_GivenSkipList_WhenGettingByIndex_ThenValueAtPosition(t *testing.T) {
+	// Given
+	list := newSeededSkipList(true)
+	for i := 999; i >= 0; i-- {
+		list.Add(i * 2)
+	}
+
+	// When/Then
+	for i := 0; i < 1000; i++ {
+		value, err := list.GetByIndex(i)
+		assert.NoError(t, err)
+		assert.Equal(t, i*2, value)
+	}
+	_, err := list.GetByIndex(1000)
+	assert.Error(t, err)
+	_, err = list.GetByIndex(-1)
+	assert.Error(t, err)
+}
+
+func Test_GivenSkipList_WhenDeletingValues_ThenPositionsAndLinksStayConsistent(t *testing.T) {
+	// Given
+	list := newSeededSkipList(true)
+	for i := 0; i < 300; i++ {
+		list.Add(i)
+	}
+
+	// When
+	for i := 0; i < 300; i += 3 {
+		list.Delete(i)
+	}
+
+	// Then
+	var expected []int
+	for i := 0; i < 300; i++ {
+		if i%3 != 0 {
+			expected = append(expected, i)
+		}
+	}
+	assert.Equal(t, expected, slices.Collect(list.All()))
+	assert.Equal(t, len(expected), list.Count())
+	for i, v := range expected {
+		position, found := list.FindPosition(v)
+		assert.True(t, found)
+		assert.Equal(t, i, position)
+		byIndex, _ := list.GetByIndex(i)
+		assert.Equal(t, v, byIndex)
+	}
+	slices.Reverse(expected)
+	assert.Equal(t, expected, slices.Collect(list.Backward()))
+}
+
+func Test_GivenSkipListWithDuplicates_WhenDeleting_ThenOnlyOneOccurrenceRemoved(t *testing.T) {
+	// Given
+	list := makeSkipList(2, 1, 2, 3, 2)
+
+	// When
+	list.Delete(2)
+	list.Delete(7)
+
+	// Then
+	assert.Equal(t, []int{1, 2, 2, 3}, slices.Collect(list.All()))
+	assert.Equal(t, 4, list.Count())
+}
+
+func Test_GivenSkipList_WhenDeletingEverything_ThenEmpty(t *testing.T) {
+	// Given
+	list := makeSkipList(1, 2, 3)
+
+	// When
+	list.Delete(2)
+	list.Delete(3)
+	list.Delete(1)
+
+	// Then
+	assert.Equal(t, 0, list.Count())
+	assert.Empty(t, slices.Collect(list.All()))
+	assert.Empty(t, slices.Collect(list.Backward()))
+	list.Add(5)
+	assert.Equal(t, []int{5}, slices.Collect(list.Backward()))
+}
+
+func Test_GivenSkipList_WhenAddingDuringIteration_ThenPanics(t *testing.T) {
+	// Given
+	list := makeSkipList(1, 2, 3)
+
+	// When/Then
+	assert.PanicsWithError(t, errModifiedDuringIteration.Error(), func() {
+		for v := range list.All() {
+			list.Add(v)
+		}
+	})
+}
+
+// TASK 12: ORDERED LIST BST
+
+func makeBST(asc bool, values ...int) *OrderedListBST[int] {
+	list := &OrderedListBST[int]{}
+	list.Clear(asc)
+	for _, v := range values {
+		list.Add(v)
+	}
+	return list
+}
+
+func bstValues(list *OrderedListBST[int]) []int {
+	values := make([]int, 0, list.Count())
+	for i := 0; i < list.Count(); i++ {
+		v, _ := list.GetByIndex(i)
+		values = append(values, v)
+	}
+	return values
+}
+
+// sizes and heights must match the children and no node may be out of balance
+func assertAVL(t *testing.T, node *BSTNode[int]) {
+	if node == nil {
+		return
+	}
+	assertAVL(t, node.left)
+	assertAVL(t, node.right)
+	assert.Equal(t, nodeSize(node.left)+nodeSize(node.right)+1, node.size)
+	assert.Equal(t, max(nodeHeight(node.left), nodeHeight(node.right))+1, node.height)
+	assert.LessOrEqual(t, nodeHeight(node.left)-nodeHeight(node.right), 1)
+	assert.GreaterOrEqual(t, nodeHeight(node.left)-nodeHeight(node.right), -1)
+}
+
+func Test_GivenValuesInAnyOrder_WhenAddingToAscendingBST_ThenIndexOrderAscending(t *testing.T) {
+	// Given
+	list := makeBST(true)
+
+	// When
+	for i := 0; i < 300; i++ {
+		list.Add((i * 7919) % 300)
+	}
+
+	// Then
+	values := bstValues(list)
+	assert.Len(t, values, 300)
+	assert.True(t, slices.IsSorted(values))
+	assertAVL(t, list.root)
+}
+
+func Test_GivenDescendingBST_WhenAdding_ThenIndexOrderDescending(t *testing.T) {
+	// Given
+	list := makeBST(false)
+
+	// When
+	for _, v := range []int{3, 1, 4, 1, 5, 9, 2, 6} {
+		list.Add(v)
+	}
+
+	// Then
+	assert.Equal(t, []int{9, 6, 5, 4, 3, 2, 1, 1}, bstValues(list))
+	assertAVL(t, list.root)
+}
+
+func Test_GivenSortedInput_WhenAddingToBST_ThenTreeStaysLogarithmic(t *testing.T) {
+	// Given
+	list := makeBST(true)
+
+	// When
+	for i := 0; i < 1024; i++ {
+		list.Add(i)
+	}
+
+	// Then
+	assert.Equal(t, 1024, list.Count())
+	assert.LessOrEqual(t, list.root.height, 15)
+	assertAVL(t, list.root)
+}
+
+func Test_GivenBST_WhenFindingPosition_ThenSameAsOrderedList(t *testing.T) {
+	for _, asc := range []bool{true, false} {
+		// Given
+		values := []int{40, 10, 30, 10, 20, 50, 30}
+		tree := makeBST(asc, values...)
+		list := OrderedList[int]{}
+		list.Clear(asc)
+		for _, v := range values {
+			list.Add(v)
+		}
+
+		for _, v := range []int{0, 10, 15, 20, 30, 35, 50, 60} {
+			// When
+			treePosition, treeFound := tree.FindPosition(v)
+
+			// Then
+			listPosition, listFound := list.FindPosition(v)
+			assert.Equal(t, listPosition, treePosition, "position of %d, asc %v", v, asc)
+			assert.Equal(t, listFound, treeFound, "found %d, asc %v", v, asc)
+		}
+	}
+}
+
+func Test_GivenBST_WhenFinding_ThenExistingValueReturned(t *testing.T) {
+	// Given
+	list := makeBST(true, 5, 3, 8)
+
+	// When
+	node, err := list.Find(8)
+	_, missingErr := list.Find(7)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 8, node.value)
+	assert.Error(t, missingErr)
+}
+
+func Test_GivenEmptyBST_WhenFinding_ThenError(t *testing.T) {
+	// Given
+	list := makeBST(true)
+
+	// When
+	_, err := list.Find(1)
+
+	// Then
+	assert.Error(t, err)
+	assert.Equal(t, 0, list.Count())
+}
+
+func Test_GivenBST_WhenDeletingValues_ThenRemainingValuesBalancedAndIndexed(t *testing.T) {
+	// Given
+	list := makeBST(true)
+	for i := 0; i < 300; i++ {
+		list.Add(i)
+	}
+
+	// When
+	for i := 0; i < 300; i += 3 {
+		list.Delete(i)
+	}
+	list.Delete(1000)
+
+	// Then
+	var expected []int
+	for i := 0; i < 300; i++ {
+		if i%3 != 0 {
+			expected = append(expected, i)
+		}
+	}
+	assert.Equal(t, expected, bstValues(list))
+	assertAVL(t, list.root)
+	for i, v := range expected {
+		position, found := list.FindPosition(v)
+		assert.True(t, found)
+		assert.Equal(t, i, position)
+	}
+}
+
+func Test_GivenBSTWithDuplicates_WhenDeleting_ThenOnlyOneOccurrenceRemoved(t *testing.T) {
+	// Given
+	list := makeBST(true, 2, 1, 2, 3, 2)
+
+	// When
+	list.Delete(2)
+
+	// Then
+	assert.Equal(t, []int{1, 2, 2, 3}, bstValues(list))
+	assertAVL(t, list.root)
+}
+
+func Test_GivenBST_WhenClearing_ThenEmptyWithNewDirection(t *testing.T) {
+	// Given
+	list := makeBST(true, 1, 2, 3)
+
+	// When
+	list.Clear(false)
+	list.Add(1)
+	list.Add(2)
+
+	// Then
+	assert.Equal(t, []int{2, 1}, bstValues(list))
+}
+
+// SKIP LIST - ORDERED LIST API
+
+func makeDescSkipList(values ...int) *SkipList[int] {
+	list := newSeededSkipList(false)
+	for _, v := range values {
+		list.Add(v)
+	}
+	return list
+}
+
+// every link width must be the number of bottom level steps it jumps over
+func assertSkipListWidths(t *testing.T, list *SkipList[int]) {
+	positions := map[*SkipNode[int]]int{list.head: 0}
+	position := 0
+	for temp := list.head.next[0]; temp != nil; temp = temp.next[0] {
+		position++
+		positions[temp] = position
+	}
+	for node := range positions {
+		for i := 0; i < len(node.next) && i < list.level; i++ {
+			target := list.count + 1
+			if node.next[i] != nil {
+				target = positions[node.next[i]]
+			}
+			assert.Equal(t, target-positions[node], node.width[i])
+		}
+	}
+}
+
+func Test_GivenSkipList_WhenIteratingFromPosition_ThenStartsThereAndOutOfRangeIsClamped(t *testing.T) {
+	// Given
+	list := makeSkipList(5, 1, 4, 2, 3)
+
+	// When / Then
+	assert.Equal(t, []int{3, 4, 5}, slices.Collect(list.AllFrom(2)))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, slices.Collect(list.AllFrom(-1)))
+	assert.Empty(t, slices.Collect(list.AllFrom(5)))
+}
+
+func Test_GivenEmptySkipList_WhenIteratingFromAnyPosition_ThenNothingYielded(t *testing.T) {
+	// Given
+	list := newSeededSkipList(true)
+
+	// When / Then
+	for _, position := range []int{-1, 0, 1} {
+		assert.Empty(t, slices.Collect(list.AllFrom(position)))
+	}
+}
+
+func Test_GivenDescendingSkipList_WhenAdding_ThenValuesDescending(t *testing.T) {
+	// Given
+	list := newSeededSkipList(false)
+
+	// When
+	for _, v := range []int{3, 1, 4, 1, 5, 9, 2, 6} {
+		list.Add(v)
+	}
+
+	// Then
+	assert.Equal(t, []int{9, 6, 5, 4, 3, 2, 1, 1}, slices.Collect(list.All()))
+	assert.Equal(t, []int{1, 1, 2, 3, 4, 5, 6, 9}, slices.Collect(list.Backward()))
+	position, found := list.FindPosition(4)
+	assert.Equal(t, 3, position)
+	assert.True(t, found)
+	assertSkipListWidths(t, list)
+}
+
+func Test_GivenSameSeed_WhenBuildingSkipLists_ThenSameShape(t *testing.T) {
+	// Given
+	first, second := newSeededSkipList(true), newSeededSkipList(true)
+
+	// When
+	for i := 0; i < 200; i++ {
+		first.Add(i)
+		second.Add(i)
+	}
+
+	// Then
+	assert.Equal(t, first.level, second.level)
+	for a, b := first.head.next[0], second.head.next[0]; a != nil; a, b = a.next[0], b.next[0] {
+		assert.Equal(t, len(a.next), len(b.next))
+	}
+}
+
+func Test_GivenSkipList_WhenFinding_ThenNodeWithValueOrError(t *testing.T) {
+	// Given
+	list := makeSkipList(5, 3, 8)
+
+	// When
+	node, err := list.Find(8)
+	_, missingErr := list.Find(7)
+	_, emptyErr := newSeededSkipList(true).Find(1)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 8, node.value)
+	assert.EqualError(t, missingErr, "not found")
+	assert.EqualError(t, emptyErr, "empty list")
+}
+
+func Test_GivenSkipList_WhenClearing_ThenEmptyWithNewDirection(t *testing.T) {
+	// Given
+	list := makeSkipList(1, 2, 3)
+
+	// When
+	list.Clear(false)
+	list.Add(1)
+	list.Add(2)
+
+	// Then
+	assert.Equal(t, []int{2, 1}, slices.Collect(list.All()))
+	assert.Equal(t, 2, list.Count())
+}
+
+func Test_GivenSkipListWithDuplicates_WhenRemovingDuplicates_ThenEachValueOnce(t *testing.T) {
+	// Given
+	list := makeSkipList(3, 1, 2, 3, 1, 1, 5, 3)
+
+	// When
+	list.RemoveDuplicates()
+
+	// Then
+	assert.Equal(t, []int{1, 2, 3, 5}, slices.Collect(list.All()))
+	assert.Equal(t, []int{5, 3, 2, 1}, slices.Collect(list.Backward()))
+	assert.Equal(t, 4, list.Count())
+	assertSkipListWidths(t, list)
+	position, found := list.FindPosition(3)
+	assert.Equal(t, 2, position)
+	assert.True(t, found)
+}
+
+func Test_GivenTwoAscendingSkipLists_WhenMerging_ThenAllValuesInOrder(t *testing.T) {
+	// Given
+	list := makeSkipList(1, 4, 7, 10)
+	toMerge := makeSkipList(2, 4, 8)
+
+	// When
+	err := list.Merge(toMerge)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 4, 4, 7, 8, 10}, slices.Collect(list.All()))
+	assert.Equal(t, 7, list.Count())
+	assert.Equal(t, []int{2, 4, 8}, slices.Collect(toMerge.All()))
+	assertSkipListWidths(t, list)
+	value, _ := list.GetByIndex(5)
+	assert.Equal(t, 8, value)
+}
+
+func Test_GivenTwoDescendingSkipLists_WhenMerging_ThenDescendingResult(t *testing.T) {
+	// Given
+	list := makeDescSkipList(9, 5, 1)
+	toMerge := makeDescSkipList(6, 2)
+
+	// When
+	err := list.Merge(toMerge)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []int{9, 6, 5, 2, 1}, slices.Collect(list.All()))
+	assert.Equal(t, []int{1, 2, 5, 6, 9}, slices.Collect(list.Backward()))
+}
+
+func Test_GivenEmptySkipList_WhenMerging_ThenOtherValuesCopied(t *testing.T) {
+	// Given
+	list := newSeededSkipList(true)
+	toMerge := makeSkipList(3, 1)
+
+	// When
+	err := list.Merge(toMerge)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, slices.Collect(list.All()))
+}
+
+func Test_GivenSkipListsWithDifferentDirection_WhenMerging_ThenError(t *testing.T) {
+	// Given
+	list := makeSkipList(1, 2)
+	toMerge := makeDescSkipList(3, 4)
+
+	// When
+	err := list.Merge(toMerge)
+
+	// Then
+	assert.EqualError(t, err, "invalid ascending flag for given list")
+	assert.Equal(t, []int{1, 2}, slices.Collect(list.All()))
+}
+
+func Test_GivenSkipList_WhenMergingWithItself_ThenError(t *testing.T) {
+	// Given
+	list := makeSkipList(1, 2)
+
+	// When
+	err := list.Merge(list)
+
+	// Then
+	assert.EqualError(t, err, "can not merge with itself")
+}
+
+func Test_GivenSkipList_WhenCheckingSublists_ThenSameAnswersAsOrderedList(t *testing.T) {
+	// Given
+	values := []int{1, 2, 2, 3, 3, 3, 4, 6}
+	skipList := makeSkipList(values...)
+	list := makeAscList(values...)
+	sublists := [][]int{{}, {1}, {2, 2, 3}, {3, 3, 3, 4}, {4, 6}, {1, 2, 2, 3, 3, 3, 4, 6}, {2, 3}, {4, 5}, {5}, {0, 1}, {6, 7}, {3, 3, 3, 3}}
+
+	for _, sub := range sublists {
+		// When
+		skipContains, skipErr := skipList.ContainsSublist(makeSkipList(sub...))
+
+		// Then
+		subList := makeAscList(sub...)
+		listContains, listErr := list.ContainsSublist(&subList)
+		assert.Equal(t, listErr, skipErr, "sublist %v", sub)
+		assert.Equal(t, listContains, skipContains, "sublist %v", sub)
+	}
+}
+
+func Test_GivenSkipListWithLongerRunThanSublist_WhenChecking_ThenMatchedAtEndOfRun(t *testing.T) {
+	// Given
+	list := makeSkipList(1, 2, 2, 2, 3, 5)
+
+	// When
+	endOfRun, endErr := list.ContainsSublist(makeSkipList(2, 3))
+	twoOfRun, _ := list.ContainsSublist(makeSkipList(2, 2, 3, 5))
+	wholeRun, _ := list.ContainsSublist(makeSkipList(2, 2, 2))
+	longerThanRun, _ := list.ContainsSublist(makeSkipList(2, 2, 2, 2))
+	skipsRun, _ := list.ContainsSublist(makeSkipList(1, 2, 3))
+
+	// Then
+	assert.NoError(t, endErr)
+	assert.True(t, endOfRun)
+	assert.True(t, twoOfRun)
+	assert.True(t, wholeRun)
+	assert.False(t, longerThanRun)
+	assert.False(t, skipsRun)
+}
+
+func Test_GivenDescendingSkipList_WhenCheckingSublist_ThenComparedInListOrder(t *testing.T) {
+	// Given
+	list := makeDescSkipList(5, 4, 4, 3, 1)
+
+	// When
+	contains, err := list.ContainsSublist(makeDescSkipList(4, 3, 1))
+	gap, _ := list.ContainsSublist(makeDescSkipList(5, 3))
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, contains)
+	assert.False(t, gap)
+}
+
+func Test_GivenSkipListsWithDifferentDirectionOrEmptyList_WhenCheckingSublist_ThenError(t *testing.T) {
+	// Given
+	list := makeSkipList(1, 2)
+	empty := newSeededSkipList(true)
+
+	// When
+	_, directionErr := list.ContainsSublist(makeDescSkipList(1))
+	_, emptyErr := empty.ContainsSublist(makeSkipList(1))
+	_, emptyBothErr := empty.ContainsSublist(newSeededSkipList(true))
+
+	// Then
+	assert.EqualError(t, directionErr, "invalid ascending flag for given list")
+	assert.EqualError(t, emptyErr, "empty list")
+	assert.EqualError(t, emptyBothErr, "empty list")
+}
+
+func Test_GivenSkipList_WhenFindingMostFrequent_ThenLongestRunValue(t *testing.T) {
+	// Given
+	list := makeSkipList(4, 1, 2, 2, 4, 3, 4, 2)
+
+	// When
+	value, err := list.MostFrequent()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 2, value)
+}
+
+func Test_GivenSkipListWithTiedRuns_WhenFindingMostFrequent_ThenFirstRunInListOrder(t *testing.T) {
+	// Given
+	list := makeDescSkipList(1, 1, 5, 5, 3)
+
+	// When
+	value, err := list.MostFrequent()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 5, value)
+}
+
+func Test_GivenEmptySkipList_WhenFindingMostFrequent_ThenError(t *testing.T) {
+	// Given
+	list := newSeededSkipList(true)
+
+	// When
+	_, err := list.MostFrequent()
+
+	// Then
+	assert.EqualError(t, err, "empty list")
+}
+
+// RANGE QUERIES
+
+func Test_GivenAscendingList_WhenTakingBounds_ThenPositionsAroundEqualRun(t *testing.T) {
+	// Given
+	list := makeAscList(10, 20, 20, 20, 30)
+
+	// When/Then
+	assert.Equal(t, 1, list.LowerBound(20))
+	assert.Equal(t, 4, list.UpperBound(20))
+	assert.Equal(t, 1, list.LowerBound(15))
+	assert.Equal(t, 1, list.UpperBound(15))
+	assert.Equal(t, 0, list.LowerBound(5))
+	assert.Equal(t, 5, list.LowerBound(35))
+	assert.Equal(t, 5, list.UpperBound(30))
+}
+
+func Test_GivenDescendingList_WhenTakingBounds_ThenPositionsInListOrder(t *testing.T) {
+	// Given
+	list := makeDescList(10, 20, 20, 20, 30)
+
+	// When/Then
+	assert.Equal(t, 1, list.LowerBound(20))
+	assert.Equal(t, 4, list.UpperBound(20))
+	assert.Equal(t, 1, list.LowerBound(25))
+	assert.Equal(t, 0, list.LowerBound(35))
+	assert.Equal(t, 5, list.UpperBound(10))
+}
+
+func Test_GivenEmptyList_WhenTakingBoundsFloorAndCeiling_ThenZeroPositionsAndErrors(t *testing.T) {
+	// Given
+	list := makeAscList()
+
+	// When
+	_, floorErr := list.Floor(1)
+	_, ceilingErr := list.Ceiling(1)
+
+	// Then
+	assert.Equal(t, 0, list.LowerBound(1))
+	assert.Equal(t, 0, list.UpperBound(1))
+	assert.Error(t, floorErr)
+	assert.Error(t, ceilingErr)
+	assert.Equal(t, 0, list.CountInRange(0, 10))
+}
+
+func Test_GivenListInBothDirections_WhenTakingFloor_ThenGreatestValueNotAbove(t *testing.T) {
+	for _, list := range []OrderedList[int]{makeAscList(10, 20, 30), makeDescList(10, 20, 30)} {
+		// When
+		exact, exactErr := list.Floor(20)
+		between, betweenErr := list.Floor(29)
+		above, aboveErr := list.Floor(100)
+		_, belowErr := list.Floor(9)
+
+		// Then
+		assert.NoError(t, exactErr)
+		assert.Equal(t, 20, exact)
+		assert.NoError(t, betweenErr)
+		assert.Equal(t, 20, between)
+		assert.NoError(t, aboveErr)
+		assert.Equal(t, 30, above)
+		assert.Error(t, belowErr)
+	}
+}
+
+func Test_GivenListInBothDirections_WhenTakingCeiling_ThenLeastValueNotBelow(t *testing.T) {
+	for _, list := range []OrderedList[int]{makeAscList(10, 20, 30), makeDescList(10, 20, 30)} {
+		// When
+		exact, exactErr := list.Ceiling(20)
+		between, betweenErr := list.Ceiling(11)
+		below, belowErr := list.Ceiling(-5)
+		_, aboveErr := list.Ceiling(31)
+
+		// Then
+		assert.NoError(t, exactErr)
+		assert.Equal(t, 20, exact)
+		assert.NoError(t, betweenErr)
+		assert.Equal(t, 20, between)
+		assert.NoError(t, belowErr)
+		assert.Equal(t, 10, below)
+		assert.Error(t, aboveErr)
+	}
+}
+
+func Test_GivenAscendingList_WhenRanging_ThenInclusiveValuesAscending(t *testing.T) {
+	// Given
+	list := makeAscList(5, 10, 15, 15, 20, 25)
+
+	// When
+	values := slices.Collect(list.Range(10, 20))
+
+	// Then
+	assert.Equal(t, []int{10, 15, 15, 20}, values)
+	assert.Equal(t, 4, list.CountInRange(10, 20))
+	assert.Equal(t, 2, list.CountInRange(11, 19))
+}
+
+func Test_GivenDescendingList_WhenRanging_ThenInclusiveValuesDescending(t *testing.T) {
+	// Given
+	list := makeDescList(5, 10, 15, 15, 20, 25)
+
+	// When
+	values := slices.Collect(list.Range(10, 20))
+
+	// Then
+	assert.Equal(t, []int{20, 15, 15, 10}, values)
+	assert.Equal(t, 4, list.CountInRange(10, 20))
+}
+
+func Test_GivenList_WhenRangingOutsideOrReversedBounds_ThenNothingYielded(t *testing.T) {
+	// Given
+	list := makeAscList(5, 10, 15)
+
+	// When/Then
+	assert.Empty(t, slices.Collect(list.Range(20, 30)))
+	assert.Empty(t, slices.Collect(list.Range(11, 14)))
+	assert.Empty(t, slices.Collect(list.Range(15, 5)))
+	assert.Equal(t, 0, list.CountInRange(15, 5))
+	assert.Equal(t, []int{5, 10, 15}, slices.Collect(list.Range(0, 100)))
+}
+
+func Test_GivenList_WhenAddingDuringRange_ThenPanics(t *testing.T) {
+	// Given
+	list := makeAscList(1, 2, 3)
+
+	// When/Then
+	assert.PanicsWithError(t, errModifiedDuringIteration.Error(), func() {
+		for v := range list.Range(1, 3) {
+			list.Add(v)
+		}
+	})
+}
+
+// COMPARATOR VARIANT
+
+type event struct {
+	at   int
+	id   int
+	name string
+}
+
+// by time and then by id, the name does not take part in the order
+func compareEvents(a, b event) int {
+	if a.at != b.at {
+		return cmp.Compare(a.at, b.at)
+	}
+	return cmp.Compare(a.id, b.id)
+}
+
+func makeEventList(asc bool, events ...event) *OrderedListFunc[event] {
+	list := NewOrderedListFunc(asc, compareEvents)
+	for _, e := range events {
+		list.Add(e)
+	}
+	return list
+}
+
+func eventIds(list *OrderedListFunc[event]) []int {
+	var ids []int
+	for e := range list.All() {
+		ids = append(ids, e.id)
+	}
+	return ids
+}
+
+func Test_GivenEventsInAnyOrder_WhenAddingToAscendingFuncList_ThenSortedByTimeThenId(t *testing.T) {
+	// Given
+	list := NewOrderedListFunc(true, compareEvents)
+
+	// When
+	list.Add(event{at: 20, id: 1})
+	list.Add(event{at: 10, id: 3})
+	list.Add(event{at: 10, id: 2})
+	list.Add(event{at: 30, id: 4})
+	list.Add(event{at: 5, id: 5})
+
+	// Then
+	assert.Equal(t, []int{5, 2, 3, 1, 4}, eventIds(list))
+	assert.Equal(t, 5, list.Count())
+}
+
+func Test_GivenDescendingFuncList_WhenAdding_ThenReverseOrder(t *testing.T) {
+	// Given
+	list := makeEventList(false, event{at: 20, id: 1}, event{at: 10, id: 2}, event{at: 30, id: 3})
+
+	// When
+	var backward []int
+	for e := range list.Backward() {
+		backward = append(backward, e.id)
+	}
+
+	// Then
+	assert.Equal(t, []int{3, 1, 2}, eventIds(list))
+	assert.Equal(t, []int{2, 1, 3}, backward)
+}
+
+func Test_GivenFuncList_WhenFindingByComparatorEquality_ThenStoredValueReturned(t *testing.T) {
+	// Given
+	list := makeEventList(true, event{at: 10, id: 1, name: "start"}, event{at: 20, id: 2, name: "stop"})
+
+	// When
+	node, err := list.Find(event{at: 20, id: 2})
+	_, missingErr := list.Find(event{at: 20, id: 3})
+	_, emptyErr := NewOrderedListFunc(true, compareEvents).Find(event{})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "stop", node.value.name)
+	assert.EqualError(t, missingErr, "not found")
+	assert.EqualError(t, emptyErr, "empty list")
+}
+
+func Test_GivenFuncList_WhenDeletingHeadMiddleAndTail_ThenLinksConsistent(t *testing.T) {
+	// Given
+	list := makeEventList(true, event{at: 1, id: 1}, event{at: 2, id: 2}, event{at: 3, id: 3}, event{at: 4, id: 4})
+
+	// When
+	list.Delete(event{at: 1, id: 1})
+	list.Delete(event{at: 3, id: 3})
+	list.Delete(event{at: 4, id: 4})
+	list.Delete(event{at: 9, id: 9})
+
+	// Then
+	assert.Equal(t, []int{2}, eventIds(list))
+	assert.Equal(t, 1, list.Count())
+	assert.Same(t, list.head, list.tail)
+	list.Delete(event{at: 2, id: 2})
+	assert.Nil(t, list.head)
+	assert.Nil(t, list.tail)
+	assert.Equal(t, 0, list.Count())
+}
+
+func Test_GivenFuncList_WhenFindingPosition_ThenSameContractAsOrderedList(t *testing.T) {
+	// Given
+	list := makeEventList(true, event{at: 10, id: 1}, event{at: 20, id: 1}, event{at: 30, id: 1})
+
+	// When
+	position, found := list.FindPosition(event{at: 20, id: 1})
+	missingPosition, missingFound := list.FindPosition(event{at: 25, id: 1})
+	endPosition, endFound := list.FindPosition(event{at: 99, id: 1})
+
+	// Then
+	assert.Equal(t, 1, position)
+	assert.True(t, found)
+	assert.Equal(t, 2, missingPosition)
+	assert.False(t, missingFound)
+	assert.Equal(t, 3, endPosition)
+	assert.False(t, endFound)
+}
+
+func Test_GivenTwoFuncLists_WhenMerging_ThenOneSortedListWithValidLinks(t *testing.T) {
+	// Given
+	list := makeEventList(true, event{at: 1, id: 1}, event{at: 4, id: 4}, event{at: 6, id: 6})
+	toMerge := makeEventList(true, event{at: 2, id: 2}, event{at: 5, id: 5}, event{at: 7, id: 7}, event{at: 8, id: 8})
+
+	// When
+	err := list.Merge(toMerge)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 4, 5, 6, 7, 8}, eventIds(list))
+	assert.Equal(t, 7, list.Count())
+	assert.Nil(t, list.head.prev)
+	assert.Equal(t, 8, list.tail.value.id)
+	var backward []int
+	for e := range list.Backward() {
+		backward = append(backward, e.id)
+	}
+	assert.Equal(t, []int{8, 7, 6, 5, 4, 2, 1}, backward)
+}
+
+func Test_GivenEmptyFuncList_WhenMerging_ThenTakesOtherNodes(t *testing.T) {
+	// Given
+	list := NewOrderedListFunc(false, compareEvents)
+	toMerge := makeEventList(false, event{at: 1, id: 1}, event{at: 2, id: 2})
+
+	// When
+	err := list.Merge(toMerge)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 1}, eventIds(list))
+	assert.Equal(t, 1, list.tail.value.id)
+}
+
+func Test_GivenFuncListsWithDifferentDirection_WhenMergingOrCheckingSublist_ThenError(t *testing.T) {
+	// Given
+	list := makeEventList(true, event{at: 1, id: 1})
+	other := makeEventList(false, event{at: 2, id: 2})
+
+	// When
+	mergeErr := list.Merge(other)
+	_, sublistErr := list.ContainsSublist(other)
+
+	// Then
+	assert.EqualError(t, mergeErr, "invalid ascending flag for given list")
+	assert.EqualError(t, sublistErr, "invalid ascending flag for given list")
+	assert.EqualError(t, list.Merge(list), "can not merge with itself")
+}
+
+func Test_GivenFuncList_WhenCheckingSublist_ThenComparedThroughComparator(t *testing.T) {
+	// Given
+	list := makeEventList(true, event{at: 1, id: 1}, event{at: 2, id: 2}, event{at: 3, id: 3}, event{at: 4, id: 4})
+	contained := makeEventList(true, event{at: 2, id: 2, name: "renamed"}, event{at: 3, id: 3})
+	gap := makeEventList(true, event{at: 2, id: 2}, event{at: 4, id: 4})
+
+	// When
+	containedResult, containedErr := list.ContainsSublist(contained)
+	gapResult, gapErr := list.ContainsSublist(gap)
+
+	// Then
+	assert.NoError(t, containedErr)
+	assert.True(t, containedResult)
+	assert.NoError(t, gapErr)
+	assert.False(t, gapResult)
+}
+
+func Test_GivenOrderedValues_WhenUsingFuncListWithCmpCompare_ThenSameOrderAsOrderedList(t *testing.T) {
+	// Given
+	values := []int{5, 3, 9, 1, 3}
+	funcList := NewOrderedListFunc(true, cmp.Compare[int])
+	list := makeAscList(values...)
+
+	// When
+	for _, v := range values {
+		funcList.Add(v)
+	}
+
+	// Then
+	assert.Equal(t, toSlice(&list), slices.Collect(funcList.All()))
+	assert.Equal(t, -1, funcList.Compare(1, 2))
+}
+
+func Test_GivenFuncList_WhenQueryingRanges_ThenBoundsThroughComparator(t *testing.T) {
+	// Given
+	list := makeEventList(true, event{at: 1, id: 1}, event{at: 3, id: 3}, event{at: 5, id: 5}, event{at: 7, id: 7})
+
+	// When
+	var inRange []int
+	for e := range list.Range(event{at: 3, id: 3}, event{at: 6}) {
+		inRange = append(inRange, e.id)
+	}
+	floor, floorErr := list.Floor(event{at: 4})
+	ceiling, ceilingErr := list.Ceiling(event{at: 4})
+	_, belowErr := list.Floor(event{at: 0})
+
+	// Then
+	assert.Equal(t, []int{3, 5}, inRange)
+	assert.Equal(t, 2, list.CountInRange(event{at: 3, id: 3}, event{at: 6}))
+	assert.Equal(t, 1, list.LowerBound(event{at: 3, id: 3}))
+	assert.Equal(t, 2, list.UpperBound(event{at: 3, id: 3}))
+	assert.NoError(t, floorErr)
+	assert.Equal(t, 3, floor.id)
+	assert.NoError(t, ceilingErr)
+	assert.Equal(t, 5, ceiling.id)
+	assert.Error(t, belowErr)
+	assert.Empty(t, slices.Collect(list.Range(event{at: 6}, event{at: 3})))
+}
+
+func Test_GivenFuncListsWithRenamedEvents_WhenTakingSetOperations_ThenEqualByComparator(t *testing.T) {
+	// Given
+	first := makeEventList(true, event{at: 1, id: 1}, event{at: 2, id: 2}, event{at: 2, id: 2}, event{at: 3, id: 3})
+	second := makeEventList(true, event{at: 2, id: 2, name: "renamed"}, event{at: 4, id: 4})
+
+	// When
+	union, unionErr := first.Union(second, Distinct)
+	intersection, intersectionErr := first.Intersection(second, Multiset)
+	difference, differenceErr := first.Difference(second, Distinct)
+	subset, subsetErr := second.IsSubsetOf(first, Distinct)
+
+	// Then
+	assert.NoError(t, unionErr)
+	assert.NoError(t, intersectionErr)
+	assert.NoError(t, differenceErr)
+	assert.NoError(t, subsetErr)
+	assert.Equal(t, []int{1, 2, 3, 4}, eventIds(union))
+	assert.Equal(t, []int{2}, eventIds(intersection))
+	assert.Equal(t, []int{1, 3}, eventIds(difference))
+	assert.False(t, subset)
+	assert.Equal(t, []int{1, 2, 2, 3}, eventIds(first))
+}
+
+func Test_GivenFuncListsWithEqualEvents_WhenMergingAll_ThenSortedAndStableByListIndex(t *testing.T) {
+	// Given
+	list := makeEventList(false, event{at: 5, id: 5, name: "list"}, event{at: 1, id: 1})
+	first := makeEventList(false, event{at: 6, id: 6}, event{at: 5, id: 5, name: "first"})
+	second := makeEventList(false, event{at: 5, id: 5, name: "second"}, event{at: 2, id: 2})
+
+	// When
+	err := list.MergeAll(first, second)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []int{6, 5, 5, 5, 2, 1}, eventIds(list))
+	var names []string
+	for e := range list.Range(event{at: 5, id: 5}, event{at: 5, id: 5}) {
+		names = append(names, e.name)
+	}
+	assert.Equal(t, []string{"list", "first", "second"}, names)
+	assert.Equal(t, 1, list.tail.value.id)
+}
+
+func Test_GivenEvents_WhenBuildingFuncListInBulk_ThenSortedStableAndFingerSet(t *testing.T) {
+	// Given
+	unsorted := []event{{at: 3, id: 3}, {at: 1, id: 1, name: "a"}, {at: 2, id: 2}, {at: 1, id: 1, name: "b"}}
+
+	// When
+	built := FromUnsortedFunc(true, compareEvents, unsorted...)
+	_, outOfOrderErr := FromSortedFunc(false, compareEvents, event{at: 1, id: 1}, event{at: 2, id: 2})
+	built.Add(event{at: 2, id: 7})
+
+	// Then
+	assert.Equal(t, []int{1, 1, 2, 7, 3}, eventIds(built))
+	assert.Equal(t, "a", built.head.value.name)
+	assert.Equal(t, "b", built.head.next.value.name)
+	assert.Equal(t, 7, built.finger.value.id)
+	assert.EqualError(t, outOfOrderErr, "values are not sorted in the given order")
+	assert.Equal(t, 3, unsorted[0].id)
+}
+
+func Test_GivenFuncListWithLongerRunThanSublistStartsWith_WhenChecking_ThenReturnsTrue(t *testing.T) {
+	// Given
+	list := makeEventList(true, event{at: 1, id: 1}, event{at: 2, id: 2}, event{at: 2, id: 2}, event{at: 3, id: 3})
+	subList := makeEventList(true, event{at: 2, id: 2}, event{at: 3, id: 3})
+
+	// When
+	result, err := list.ContainsSublist(subList)
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, result)
+}
+
+// SET ALGEBRA
+
+func Test_GivenTwoAscendingLists_WhenTakingSetOperations_ThenDistinctResults(t *testing.T) {
+	// Given
+	first := makeAscList(1, 2, 2, 3, 5, 5, 5)
+	second := makeAscList(2, 3, 3, 4, 5)
+
+	// When
+	union, unionErr := first.Union(&second, Distinct)
+	intersection, intersectionErr := first.Intersection(&second, Distinct)
+	difference, differenceErr := first.Difference(&second, Distinct)
+	symmetric, symmetricErr := first.SymmetricDifference(&second, Distinct)
+
+	// Then
+	assert.NoError(t, unionErr)
+	assert.NoError(t, intersectionErr)
+	assert.NoError(t, differenceErr)
+	assert.NoError(t, symmetricErr)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, toSlice(union))
+	assert.Equal(t, []int{2, 3, 5}, toSlice(intersection))
+	assert.Equal(t, []int{1}, toSlice(difference))
+	assert.Equal(t, []int{1, 4}, toSlice(symmetric))
+	assert.Equal(t, 5, union.Count())
+}
+
+func Test_GivenTwoAscendingLists_WhenTakingMultisetOperations_ThenCountsCombined(t *testing.T) {
+	// Given
+	first := makeAscList(1, 2, 2, 3, 5, 5, 5)
+	second := makeAscList(2, 3, 3, 4, 5)
+
+	// When
+	union, _ := first.Union(&second, Multiset)
+	intersection, _ := first.Intersection(&second, Multiset)
+	difference, _ := first.Difference(&second, Multiset)
+	symmetric, _ := first.SymmetricDifference(&second, Multiset)
+
+	// Then
+	assert.Equal(t, []int{1, 2, 2, 3, 3, 4, 5, 5, 5}, toSlice(union))
+	assert.Equal(t, []int{2, 3, 5}, toSlice(intersection))
+	assert.Equal(t, []int{1, 2, 5, 5}, toSlice(difference))
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 5}, toSlice(symmetric))
+	assert.Equal(t, 6, symmetric.Count())
+}
+
+func Test_GivenTwoDescendingLists_WhenTakingOperations_ThenResultDescending(t *testing.T) {
+	// Given
+	first := makeDescList(1, 3, 5, 7)
+	second := makeDescList(3, 4, 7, 8)
+
+	// When
+	union, _ := first.Union(&second, Distinct)
+	intersection, _ := first.Intersection(&second, Distinct)
+	difference, _ := first.Difference(&second, Multiset)
+
+	// Then
+	assert.Equal(t, []int{8, 7, 5, 4, 3, 1}, toSlice(union))
+	assert.Equal(t, []int{7, 3}, toSlice(intersection))
+	assert.Equal(t, []int{5, 1}, toSlice(difference))
+	assert.Equal(t, []int{1, 3, 4, 5, 7, 8}, slices.Collect(union.Backward()))
+}
+
+func Test_GivenListOperation_WhenDone_ThenInputsUnchanged(t *testing.T) {
+	// Given
+	first := makeAscList(1, 2, 3)
+	second := makeAscList(2, 3, 4)
+
+	// When
+	union, _ := first.Union(&second, Multiset)
+	union.Add(10)
+
+	// Then
+	assert.Equal(t, []int{1, 2, 3}, toSlice(&first))
+	assert.Equal(t, []int{2, 3, 4}, toSlice(&second))
+	assert.Equal(t, []int{1, 2, 3, 4, 10}, toSlice(union))
+}
+
+func Test_GivenEmptyList_WhenTakingOperations_ThenOtherOrEmpty(t *testing.T) {
+	// Given
+	empty := makeAscList()
+	other := makeAscList(1, 1, 2)
+
+	// When
+	union, _ := empty.Union(&other, Multiset)
+	intersection, _ := empty.Intersection(&other, Multiset)
+	difference, _ := other.Difference(&empty, Distinct)
+
+	// Then
+	assert.Equal(t, []int{1, 1, 2}, toSlice(union))
+	assert.Empty(t, toSlice(intersection))
+	assert.Equal(t, 0, intersection.Count())
+	assert.Equal(t, []int{1, 2}, toSlice(difference))
+}
+
+func Test_GivenListsWithDifferentDirection_WhenTakingOperations_ThenSameErrorAsMerge(t *testing.T) {
+	// Given
+	first := makeAscList(1, 2)
+	second := makeDescList(1, 2)
+
+	// When
+	_, unionErr := first.Union(&second, Distinct)
+	_, intersectionErr := first.Intersection(&second, Distinct)
+	_, differenceErr := first.Difference(&second, Distinct)
+	_, symmetricErr := first.SymmetricDifference(&second, Distinct)
+	_, subsetErr := first.IsSubsetOf(&second, Distinct)
+
+	// Then
+	mergeErr := first.Merge(&second)
+	for _, err := range []error{unionErr, intersectionErr, differenceErr, symmetricErr, subsetErr} {
+		assert.EqualError(t, err, mergeErr.Error())
+	}
+}
+
+func Test_GivenLists_WhenCheckingSubset_ThenSetAndMultisetDiffer(t *testing.T) {
+	// Given
+	small := makeAscList(2, 2, 5)
+	large := makeAscList(1, 2, 3, 5, 8)
+	empty := makeAscList()
+
+	// When
+	setResult, setErr := small.IsSubsetOf(&large, Distinct)
+	multisetResult, multisetErr := small.IsSubsetOf(&large, Multiset)
+
+	// Then
+	assert.NoError(t, setErr)
+	assert.True(t, setResult)
+	assert.NoError(t, multisetErr)
+	assert.False(t, multisetResult)
+	emptyResult, _ := empty.IsSubsetOf(&small, Multiset)
+	assert.True(t, emptyResult)
+	largeResult, _ := large.IsSubsetOf(&small, Distinct)
+	assert.False(t, largeResult)
+	selfResult, _ := small.IsSubsetOf(&small, Multiset)
+	assert.True(t, selfResult)
+}
+
+// K-WAY MERGE
+
+func Test_GivenManyAscendingLists_WhenMergingAll_ThenOneSortedList(t *testing.T) {
+	// Given
+	list := makeAscList(5, 15, 25)
+	first := makeAscList(1, 2, 30)
+	second := makeAscList(15, 16)
+	third := makeAscList(0, 100)
+
+	// When
+	err := list.MergeAll(&first, &second, &third)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 5, 15, 15, 16, 25, 30, 100}, toSlice(&list))
+	assert.Equal(t, []int{100, 30, 25, 16, 15, 15, 5, 2, 1, 0}, slices.Collect(list.Backward()))
+	assert.Equal(t, 10, list.Count())
+	assert.Nil(t, list.head.prev)
+	assert.Nil(t, list.tail.next)
+}
+
+func Test_GivenDescendingLists_WhenMergingAll_ThenDescendingResult(t *testing.T) {
+	// Given
+	list := makeDescList(9, 3)
+	first := makeDescList(10, 4, 1)
+	second := makeDescList(7)
+
+	// When
+	err := list.MergeAll(&first, &second)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []int{10, 9, 7, 4, 3, 1}, toSlice(&list))
+	assert.Equal(t, 1, list.tail.value)
+}
+
+func Test_GivenEmptyListAndEmptyInputs_WhenMergingAll_ThenOnlyNonEmptyValuesTaken(t *testing.T) {
+	// Given
+	list := makeAscList()
+	empty := makeAscList()
+	first := makeAscList(3, 1)
+
+	// When
+	err := list.MergeAll(&empty, &first)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, toSlice(&list))
+	assert.Equal(t, 2, list.Count())
+}
+
+func Test_GivenNoLists_WhenMergingAll_ThenListUnchanged(t *testing.T) {
+	// Given
+	list := makeAscList(2, 1)
+
+	// When
+	err := list.MergeAll()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, toSlice(&list))
+}
+
+func Test_GivenManyRandomPartitions_WhenMergingAll_ThenSameAsSortingEverything(t *testing.T) {
+	// Given
+	random := rand.New(rand.NewSource(42))
+	list := makeAscList()
+	var partitions []*OrderedList[int]
+	var everything []int
+	for i := 0; i < 30; i++ {
+		partition := makeAscList()
+		for j := random.Intn(20); j > 0; j-- {
+			v := random.Intn(100)
+			partition.Add(v)
+			everything = append(everything, v)
+		}
+		partitions = append(partitions, &partition)
+	}
+
+	// When
+	err := list.MergeAll(partitions...)
+
+	// Then
+	assert.NoError(t, err)
+	slices.Sort(everything)
+	assert.Equal(t, everything, toSlice(&list))
+	assert.Equal(t, len(everything), list.Count())
+}
+
+func Test_GivenInvalidInputs_WhenMergingAll_ThenErrorAndListUnchanged(t *testing.T) {
+	// Given
+	list := makeAscList(1, 2)
+	ascending := makeAscList(3)
+	descending := makeDescList(4)
+
+	// When
+	directionErr := list.MergeAll(&ascending, &descending)
+	selfErr := list.MergeAll(&ascending, &list)
+	twiceErr := list.MergeAll(&ascending, &ascending)
+
+	// Then
+	assert.EqualError(t, directionErr, "invalid ascending flag for given list")
+	assert.EqualError(t, selfErr, "can not merge with itself")
+	assert.EqualError(t, twiceErr, "list given more than once")
+	assert.Equal(t, []int{1, 2}, toSlice(&list))
+	assert.Equal(t, []int{3}, toSlice(&ascending))
+}
+
+// BULK CONSTRUCTION AND FINGER
+
+func Test_GivenSortedValues_WhenBuildingFromSorted_ThenLinkedInGivenOrder(t *testing.T) {
+	// When
+	ascending, ascErr := FromSorted(true, 1, 2, 2, 5)
+	descending, descErr := FromSorted(false, 5, 2, 2, 1)
+
+	// Then
+	assert.NoError(t, ascErr)
+	assert.NoError(t, descErr)
+	assert.Equal(t, []int{1, 2, 2, 5}, toSlice(ascending))
+	assert.Equal(t, []int{1, 2, 2, 5}, slices.Collect(descending.Backward()))
+	assert.Equal(t, 4, ascending.Count())
+	assert.Nil(t, ascending.head.prev)
+	assert.Equal(t, 5, ascending.tail.value)
+}
+
+func Test_GivenValuesOutOfOrder_WhenBuildingFromSorted_ThenError(t *testing.T) {
+	// When
+	_, ascErr := FromSorted(true, 1, 3, 2)
+	_, descErr := FromSorted(false, 1, 2)
+
+	// Then
+	assert.EqualError(t, ascErr, "values are not sorted in the given order")
+	assert.EqualError(t, descErr, "values are not sorted in the given order")
+}
+
+func Test_GivenNoValues_WhenBuildingFromSorted_ThenEmptyListWithDirection(t *testing.T) {
+	// When
+	list, err := FromSorted[int](false)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 0, list.Count())
+	list.Add(1)
+	list.Add(2)
+	assert.Equal(t, []int{2, 1}, toSlice(list))
+}
+
+func Test_GivenUnsortedValues_WhenBuildingFromUnsorted_ThenSortedAndInputUntouched(t *testing.T) {
+	// Given
+	values := []int{4, 1, 3, 1, 2}
+
+	// When
+	ascending := FromUnsorted(true, values...)
+	descending := FromUnsorted(false, values...)
+
+	// Then
+	assert.Equal(t, []int{1, 1, 2, 3, 4}, toSlice(ascending))
+	assert.Equal(t, []int{4, 3, 2, 1, 1}, toSlice(descending))
+	assert.Equal(t, []int{4, 1, 3, 1, 2}, values)
+}
+
+func Test_GivenBuiltList_WhenAddingAndDeleting_ThenBehavesLikeNormalList(t *testing.T) {
+	// Given
+	list := FromUnsorted(true, 10, 30, 20)
+
+	// When
+	list.Add(25)
+	list.Delete(10)
+	list.Add(5)
+
+	// Then
+	assert.Equal(t, []int{5, 20, 25, 30}, toSlice(list))
+	position, found := list.FindPosition(25)
+	assert.Equal(t, 2, position)
+	assert.True(t, found)
+}
+
+func Test_GivenRandomValues_WhenAddingWithFinger_ThenSameOrderAsSorting(t *testing.T) {
+	for _, asc := range []bool{true, false} {
+		// Given
+		random := rand.New(rand.NewSource(7))
+		list := OrderedList[int]{}
+		list.Clear(asc)
+		var values []int
+
+		// When
+		for i := 0; i < 300; i++ {
+			v := random.Intn(50)
+			list.Add(v)
+			values = append(values, v)
+		}
+
+		// Then
+		slices.Sort(values)
+		if !asc {
+			slices.Reverse(values)
+		}
+		assert.Equal(t, values, toSlice(&list))
+		reversed := slices.Clone(values)
+		slices.Reverse(reversed)
+		assert.Equal(t, reversed, slices.Collect(list.Backward()))
+	}
+}
+
+func Test_GivenNearlySortedStream_WhenAdding_ThenFingerFollowsLastInsertion(t *testing.T) {
+	// Given
+	list := makeAscList(0, 1000)
+
+	// When
+	for _, v := range []int{10, 12, 11, 14, 13, 15} {
+		list.Add(v)
+
+		// Then
+		assert.Equal(t, v, list.finger.value)
+	}
+	assert.Equal(t, []int{0, 10, 11, 12, 13, 14, 15, 1000}, toSlice(&list))
+}
+
+func Test_GivenFingerOnRemovedNode_WhenAddingAfterDeleteOrRemoveDuplicates_ThenOrderKept(t *testing.T) {
+	// Given
+	list := makeAscList(1, 5, 9)
+	list.Add(5)
+
+	// When
+	list.RemoveDuplicates()
+	list.Add(6)
+	list.Delete(6)
+	list.Add(4)
+	list.Add(8)
+
+	// Then
+	assert.Equal(t, []int{1, 4, 5, 8, 9}, toSlice(&list))
+	assert.Equal(t, []int{9, 8, 5, 4, 1}, slices.Collect(list.Backward()))
+}
@@ -1,31 +1,66 @@
 package ordered_list
 
 import (
+	"cmp"
 	"constraints"
 	// "os"
 	"errors"
+	"iter"
 )
 
-type Node[T constraints.Ordered] struct {
+// the node does not compare anything, so it keeps any type
+type Node[T any] struct {
 	prev  *Node[T]
 	next  *Node[T]
 	value T
 }
 
+var errModifiedDuringIteration = errors.New("collection was modified during iteration")
+
+// the list itself only needs to know how two values compare, everything else is the same for numbers and for structs. So there
+// is one implementation - listCore - and the order is its second type parameter. OrderedList embeds it with cmp.Compare behind
+// an empty struct, so the zero value is still a ready to use list, OrderedListFunc embeds it with the function from the caller,
+// see the comparator variant in solution-2.go
+type comparator[T any] interface {
+	compare(a, b T) int
+}
+
+type naturalOrder[T constraints.Ordered] struct{}
+
+func (naturalOrder[T]) compare(a, b T) int {
+	return cmp.Compare(a, b)
+}
+
 type OrderedList[T constraints.Ordered] struct {
+	listCore[T, naturalOrder[T]]
+}
+
+func NewOrderedList[T constraints.Ordered](asc bool) *OrderedList[T] {
+	l := &OrderedList[T]{}
+	l._ascending = asc
+	return l
+}
+
+type listCore[T any, C comparator[T]] struct {
 	head       *Node[T]
 	tail       *Node[T]
 	count      int
 	_ascending bool
+	version    int
+	// the last inserted node, Add starts searching from it instead of the head
+	finger *Node[T]
+	cmp    C
 }
 
-func (l *OrderedList[T]) Count() int {
+func (l *listCore[T, C]) Count() int {
 	return l.count
 }
 
-func (l *OrderedList[T]) Add(item T) {
+func (l *listCore[T, C]) Add(item T) {
 	newNode := &Node[T]{value: item}
 	l.count++
+	l.version++
+	defer func() { l.finger = newNode }()
 
 	if l.head == nil {
 		l.head = newNode
@@ -40,15 +75,14 @@ func (l *OrderedList[T]) Add(item T) {
 		return
 	}
 
-	if l.isPivot(l.tail, item,false) {
+	if l.isPivot(l.tail, item, false) {
 		newNode.prev = l.tail
 		l.tail.next = newNode
 		l.tail = newNode
 		return
 	}
 
-	toInsert := l.head
-	for ; !l.isPivot(toInsert, item, true); toInsert = toInsert.next {}
+	toInsert := l.findInsertionPoint(item)
 
 	newNode.next = toInsert
 	newNode.prev = toInsert.prev
@@ -56,89 +90,116 @@ func (l *OrderedList[T]) Add(item T) {
 	toInsert.prev = newNode
 }
 
-func (l *OrderedList[T]) isPivot(node *Node[T], item T, before bool) bool {
+// first node which is not before the item, the same node the walk from the head would stop at. The head and the tail were
+// already checked by Add, so the node is somewhere in the middle. Near sorted input inserts next to the previous item, so the
+// walk from the finger is only a few steps in either direction
+func (l *listCore[T, C]) findInsertionPoint(item T) *Node[T] {
+	if l.finger == nil {
+		l.finger = l.head
+	}
+
+	toInsert := l.finger
+
+	if l.isPivot(toInsert, item, true) {
+		for ; l.isPivot(toInsert.prev, item, true); toInsert = toInsert.prev {}
+		return toInsert
+	}
+
+	for ; !l.isPivot(toInsert, item, true); toInsert = toInsert.next {}
+	return toInsert
+}
+
+// whether the item goes before (or after) the node in the list order, equal values count as both
+func (l *listCore[T, C]) isPivot(node *Node[T], item T, before bool) bool {
 	if before {
-		return (l._ascending && item <= node.value) || (!l._ascending && item >= node.value)
+		return l.order(item, node.value) <= 0
+	}
+	return l.order(item, node.value) >= 0
+}
+
+// the comparator turned to the list direction - negative when a comes first in the list
+func (l *listCore[T, C]) order(a T, b T) int {
+	if l._ascending {
+		return l.cmp.compare(a, b)
 	}
-	return (l._ascending && item >= node.value) || (!l._ascending && item <= node.value)
+	return l.cmp.compare(b, a)
+}
+
+func (l *listCore[T, C]) equal(a T, b T) bool {
+	return l.cmp.compare(a, b) == 0
 }
 
-func (l *OrderedList[T]) Find(n T) (Node[T], error) {
+func (l *listCore[T, C]) Find(n T) (Node[T], error) {
 	var result Node[T]
 
 	if l.head == nil {
 		return result, errors.New("empty list")
 	}
 
-	if !l.isPivot(l.head, n, false) || !l.isPivot(l.tail, n, true) {
-		return result, errors.New("not found")
-	}
-
-	for temp := l.head; temp != nil; temp = temp.next {
-		if temp.value == n {
-			return *temp, nil
-		}
-		if l.isPivot(temp, n, true) {
-			break
-		}
+	if node := l.findNode(n); node != nil {
+		return *node, nil
 	}
 
 	return result, errors.New("not found")
 }
 
-func (l *OrderedList[T]) Delete(n T) {
-	if l.head == nil || !l.isPivot(l.head, n, false) || !l.isPivot(l.tail, n, true) {
-		return
-	}
-
-	l.count--
+// removes the first occurrence of the value if there is any
+func (l *listCore[T, C]) Delete(n T) {
+	toDelete := l.findNode(n)
 
-	if l.head == l.tail {
-		l.Clear(l._ascending)
+	if toDelete == nil {
 		return
 	}
 
-	if l.head.value == n {
-		l.head = l.head.next
-		l.head.prev = nil
-		return
+	if toDelete.prev != nil {
+		toDelete.prev.next = toDelete.next
+	} else {
+		l.head = toDelete.next
 	}
 
-	if l.tail.value == n {
-		l.tail = l.tail.prev
-		l.tail.next = nil
-		return
+	if toDelete.next != nil {
+		toDelete.next.prev = toDelete.prev
+	} else {
+		l.tail = toDelete.prev
 	}
 
-	toDelete := l.head
-	for ; toDelete != nil && toDelete.value != n; toDelete = toDelete.next {}
+	l.count--
+	l.version++
+	l.finger = nil
+}
+
+// first node equal to the value, the search stops as soon as the value would be before the current node
+func (l *listCore[T, C]) findNode(n T) *Node[T] {
+	if l.head == nil || !l.isPivot(l.head, n, false) || !l.isPivot(l.tail, n, true) {
+		return nil
+	}
 
-	if toDelete == nil {
-		l.count++
-		return
+	for temp := l.head; temp != nil; temp = temp.next {
+		if l.equal(temp.value, n) {
+			return temp
+		}
+		if l.isPivot(temp, n, true) {
+			return nil
+		}
 	}
 
-	toDelete.prev.next = toDelete.next
-	toDelete.next.prev = toDelete.prev
+	return nil
 }
 
-func (l *OrderedList[T]) Clear(asc bool) {
+func (l *listCore[T, C]) Clear(asc bool) {
 	l.head = nil
 	l.tail = nil
 	l.count = 0
 	l._ascending = asc
+	l.version++
+	l.finger = nil
 }
 
-func (l * OrderedList[T]) FindPosition(value T) (int, bool) {
-	if l.head == nil {
-		return 0, false
-	}
-
+func (l *listCore[T, C]) FindPosition(value T) (int, bool) {
 	position := 0
-	current := l.head
 
-	for current != nil {
-		if current.value == value {
+	for current := l.head; current != nil; current = current.next {
+		if l.equal(current.value, value) {
 			return position, true
 		}
 
@@ -147,19 +208,55 @@ func (l * OrderedList[T]) FindPosition(value T) (int, bool) {
 			return position, false
 		}
 
-		current = current.next
 		position++
 	}
 
 	return position, false
 }
 
-func (l *OrderedList[T]) Compare(v1 T, v2 T) int {
-	if v1 < v2 {
-		return -1
+// values in the list order, so ascending or descending depending on the flag. Panics if the list is modified
+// before the iteration is over
+func (l *listCore[T, C]) All() iter.Seq[T] {
+	return l.AllFrom(0)
+}
+
+// values in the list order starting with the one at the given position. The list has no indexing, so the position
+// itself is walked to from the head
+func (l *listCore[T, C]) AllFrom(position int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		version := l.version
+		temp := l.head
+		for i := 0; i < position && temp != nil; i++ {
+			temp = temp.next
+		}
+		for ; temp != nil; temp = temp.next {
+			if !yield(temp.value) {
+				return
+			}
+			l.checkVersion(version)
+		}
+	}
+}
+
+// values in the opposite to the list order
+func (l *listCore[T, C]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		version := l.version
+		for temp := l.tail; temp != nil; temp = temp.prev {
+			if !yield(temp.value) {
+				return
+			}
+			l.checkVersion(version)
+		}
 	}
-	if v1 > v2 {
-		return +1
+}
+
+func (l *listCore[T, C]) checkVersion(version int) {
+	if l.version != version {
+		panic(errModifiedDuringIteration)
 	}
-	return 0
+}
+
+func (l *listCore[T, C]) Compare(v1 T, v2 T) int {
+	return l.cmp.compare(v1, v2)
 }
@@ -2,7 +2,11 @@ package hashtable
 
 import (
 	"errors"
+	"hash/maphash"
+	"iter"
+	"math/bits"
 	"math/rand"
+	"slices"
 	"time"
 )
 
@@ -15,7 +19,8 @@ import (
 * now I know it :) If someone modifies the collection during some iteration, this is the way we know about it, by comparing the
 * version. Another important point which I skipped was prime selection of the new size. But i did not want to copy the whole code.
 * As far as I understood, using prime size and coprime step visits all the indexes. And this is somehow related to generators from
-* cyclic groups :) I just doubled the size here, but C# devs of course did it properly.
+* cyclic groups :) At first I just doubled the size here, but C# devs of course did it properly - so now the sizes come from
+* getPrime, see task 3 + 4 continuation below.
 *
 * But the rest seems fine! We have our slots aka buckets, they store the hash code with leading bit storing whether this slot
 * already collided during inserion. This will help us during search, insertion and deletion. Then we store the key itself and
@@ -28,10 +33,13 @@ import (
 */
 
 const (
-	CollisionBit = 0x80000000
-	HashMask     = 0x7FFFFFFF
-	LoadFactor   = 0.72
-	InitialSize  = 17
+	CollisionBit    = 0x80000000
+	HashMask        = 0x7FFFFFFF
+	LoadFactor      = 0.72
+	InitialSize     = 17
+	TombstoneFactor = 0.25
+	ShrinkFactor    = LoadFactor / 4
+	MigrationStep   = 8
 )
 
 type Hashable interface {
@@ -39,175 +47,494 @@ type Hashable interface {
 	HashCode() int
 }
 
-type Slot[K Hashable] struct {
+// the key itself can not tell whether the slot is used - 0, "" or a zero struct are perfectly fine keys - so every slot
+// keeps its state explicitly. The zero value is slotEmpty, so a freshly made slots slice is empty without any init loop
+type slotState uint8
+
+const (
+	slotEmpty slotState = iota
+	slotOccupied
+	slotTombstone
+)
+
+type Slot[K comparable] struct {
 	key      K
 	hashColl int
-	deleted  bool
+	state    slotState
 }
 
-type DynamicHashSet[K Hashable] struct {
-	slots []Slot[K]
-	count int
-	loadSize int
+var errModifiedDuringIteration = errors.New("collection was modified during iteration")
+
+type DynamicHashSet[K comparable] struct {
+	slots      []Slot[K]
+	count      int
+	tombstones int
+	loadSize   int
+	version    int
+	hasher     func(K) int
+	// only for the incremental mode - the table being migrated, how many live keys are still there and the next slot to move
+	incremental bool
+	oldSlots    []Slot[K]
+	oldCount    int
+	migrated    int
 }
 
 func NewDynamicHashSet[K Hashable]() *DynamicHashSet[K] {
+	return NewDynamicHashSetFunc(func(key K) int { return key.HashCode() })
+}
+
+// for keys which can not or should not implement Hashable themselves
+func NewDynamicHashSetFunc[K comparable](hasher func(K) int) *DynamicHashSet[K] {
+	size := getPrime(InitialSize)
 	return &DynamicHashSet[K]{
-		slots:  make([]Slot[K], InitialSize),
+		slots:    make([]Slot[K], size),
 		count:    0,
-		loadSize: int(LoadFactor * float64(InitialSize)),
+		loadSize: loadSizeFor(size),
+		hasher:   hasher,
 	}
 }
 
 func (hs *DynamicHashSet[K]) Insert(key K) error {
-	if hs.count >= hs.loadSize {
-		hs.resize(len(hs.slots) * 2)
+	if hs.Find(key) {
+		return errors.New("duplicate key")
+	}
+
+	if hs.count-hs.oldCount+hs.tombstones >= hs.loadSize {
+		hs.makeRoom()
 	}
 
+	hs.migrate(MigrationStep)
+
 	hash, seed, step := hs.hashKey(key)
-	idx := seed
-	firstDeletedSlot := -1
+	idx, found := seekFreeSlot(hs.slots, key, hash, seed, step)
 
-	for i := 0; i < len(hs.slots); i++ {
-		currentSlot := &hs.slots[idx]
+	if found {
+		return errors.New("duplicate key")
+	}
 
-		if firstDeletedSlot == -1 && currentSlot.isTombstone() {
-			firstDeletedSlot = idx
+	if idx == -1 {
+		return errors.New("table full")
+	}
+
+	if hs.slots[idx].isTombstone() {
+		hs.tombstones--
+	}
+
+	occupySlot(hs.slots, idx, key, hash)
+	hs.count++
+	hs.version++
+	return nil
+}
+
+func (hs *DynamicHashSet[K]) Find(key K) bool {
+	hash, seed, step := hs.hashKey(key)
+	return findSlot(hs.slots, key, hash, seed, step) != -1 || hs.findOld(key) != -1
+}
+
+func (hs *DynamicHashSet[K]) Delete(key K) error {
+	if !hs.Find(key) {
+		return errors.New("no element found")
+	}
+
+	hs.migrate(MigrationStep)
+
+	hash, seed, step := hs.hashKey(key)
+	idx := findSlot(hs.slots, key, hash, seed, step)
+
+	if idx == -1 {
+		return hs.deleteOld(key)
+	}
+
+	hs.slots[idx].state = slotTombstone
+	hs.count--
+	hs.tombstones++
+	hs.version++
+	hs.compact()
+	return nil
+}
+
+func (hs *DynamicHashSet[K]) Count() int {
+	return hs.count
+}
+
+// keys in slot order, which is of course no order at all from the outside. Empty slots and tombstones are skipped.
+// Here comes the _version from C# - if the set is modified before the iteration is over we panic instead of silently
+// skipping or repeating keys after a resize
+func (hs *DynamicHashSet[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		version := hs.version
+		for _, slots := range [][]Slot[K]{hs.oldSlots, hs.slots} {
+			for i := range slots {
+				e := &slots[i]
+				if e.isEmpty() || e.isTombstone() {
+					continue
+				}
+				if !yield(e.key) {
+					return
+				}
+				if hs.version != version {
+					panic(errModifiedDuringIteration)
+				}
+			}
 		}
+	}
+}
 
-		hasFreeSlotBehind := currentSlot.isEmpty() && firstDeletedSlot != -1
-		if hasFreeSlotBehind {
-			hs.insertAt(firstDeletedSlot, key, hash)
-			return nil
-		} else if currentSlot.isEmpty() {
-			hs.insertAt(idx, key, hash)
-			return nil
+func (hs *DynamicHashSet[K]) hashKey(key K) (hash int, seed int, step int) {
+	return probeStart(hs.hasher(key), len(hs.slots))
+}
+
+func (hs *DynamicHashSet[K]) resize(newSize int) {
+	hs.finishMigration()
+	oldEntries := hs.slots
+	hs.slots = make([]Slot[K], newSize)
+	hs.count = 0
+	hs.tombstones = 0
+	hs.loadSize = loadSizeFor(newSize)
+
+	for i := 0; i < len(oldEntries); i++ {
+		e := &oldEntries[i]
+		shouldReinsert := !e.isEmpty() && !e.isTombstone()
+		if shouldReinsert {
+			hs.Insert(e.key)
 		}
+	}
+}
 
-		if currentSlot.matchesKey(hash, key) {
-			return errors.New("duplicate key")
+/*
+* 8. Hash Table - task number 3 + 4 continuation - tombstones and shrinking
+*
+* Delete only marks the slot, so after many deletions the table is full of tombstones - Find walks over them and Insert counts
+* them as used, because the probe chains do not get shorter. So now the set counts them. Insert makes room when live keys and
+* tombstones together reach the load size - if at most three quarters of it are live we only throw the tombstones away, otherwise
+* we grow. Either way at least a quarter of the load size is free afterwards, so this does not repeat on every insertion. Delete also cleans
+* up: when a quarter of all slots are tombstones the keys are rehashed into the same slots, and when less than ShrinkFactor of the
+* slots are used the table shrinks so that the keys take half of the new load size - far enough from both thresholds.
+*
+* TrimExcess and EnsureCapacity are the ones from .NET. Capacity here means how many keys fit before the next resize, so it is
+* the load size and not the number of slots.
+*/
+
+// shrinks the table to the smallest prime size which holds the current keys, tombstones are dropped on the way
+func (hs *DynamicHashSet[K]) TrimExcess() {
+	hs.resize(sizeForCapacity(hs.count))
+}
+
+// makes sure that capacity keys fit without a resize and returns the new capacity
+func (hs *DynamicHashSet[K]) EnsureCapacity(capacity int) int {
+	if capacity > hs.loadSize {
+		hs.resize(sizeForCapacity(capacity))
+	}
+	return hs.loadSize
+}
+
+func (hs *DynamicHashSet[K]) makeRoom() {
+	hs.finishMigration()
+
+	if hs.count <= hs.loadSize*3/4 {
+		hs.rebuild(len(hs.slots))
+		return
+	}
+
+	hs.rebuild(expandPrime(len(hs.slots)))
+}
+
+func (hs *DynamicHashSet[K]) compact() {
+	if hs.oldSlots != nil {
+		return
+	}
+
+	minSize := getPrime(InitialSize)
+
+	if len(hs.slots) > minSize && float64(hs.count) < ShrinkFactor*float64(len(hs.slots)) {
+		hs.rebuild(sizeForCapacity(2 * hs.count))
+		return
+	}
+
+	if float64(hs.tombstones) > TombstoneFactor*float64(len(hs.slots)) {
+		hs.rebuild(len(hs.slots))
+	}
+}
+
+// the automatic growth, rehash and shrinking - all at once or, in the incremental mode, as a migration
+func (hs *DynamicHashSet[K]) rebuild(newSize int) {
+	if hs.incremental {
+		hs.startMigration(newSize)
+		return
+	}
+
+	if newSize == len(hs.slots) {
+		hs.rehash()
+		return
+	}
+
+	hs.resize(newSize)
+}
+
+// same size, same slots slice - the live keys are copied out, the slots cleared and the keys inserted again
+func (hs *DynamicHashSet[K]) rehash() {
+	keys := make([]K, 0, hs.count)
+	for i := range hs.slots {
+		if e := &hs.slots[i]; !e.isEmpty() && !e.isTombstone() {
+			keys = append(keys, e.key)
 		}
+	}
 
-		if !hasCollisionBit(currentSlot.hashColl) {
-			hs.slots[idx].hashColl |= CollisionBit
+	clear(hs.slots)
+	hs.count = 0
+	hs.tombstones = 0
+
+	for _, key := range keys {
+		hs.Insert(key)
+	}
+}
+
+/*
+* 8. Hash Table - incremental rehashing
+*
+* The resize moves every key in one go, so the insertion which crosses the load factor is O(n) while all the others are O(1).
+* Redis does not do that - its dict keeps both tables while migrating and every operation moves a few buckets. Same here: in the
+* incremental mode the automatic growth, rehash and shrinking only allocate the new slots and remember the old ones, then every
+* Insert and Delete moves the next MigrationStep old slots. A moved slot becomes a tombstone in the old table, so the probe chains
+* of the keys which are not moved yet stay intact. New keys always go to the new table, Find and Delete look into both until the
+* old one is empty. Find does not migrate, otherwise reading the set inside All would move keys behind the iteration. For the
+* same reason Insert and Delete first check the key and only then make room or migrate - a duplicate Insert or a Delete of
+* a missing key does not bump the version, so it must not touch the slots either.
+*
+* With 8 slots per operation the old table is empty after len / 8 operations - even if all of them are insertions the new table
+* stays under the load factor, for the same size rehash too, because it starts at most three quarters full. Explicit resizes like
+* EnsureCapacity or TrimExcess first finish the migration and then work as before.
+*/
+
+func NewIncrementalDynamicHashSet[K Hashable]() *DynamicHashSet[K] {
+	return NewIncrementalDynamicHashSetFunc(func(key K) int { return key.HashCode() })
+}
+
+func NewIncrementalDynamicHashSetFunc[K comparable](hasher func(K) int) *DynamicHashSet[K] {
+	hs := NewDynamicHashSetFunc(hasher)
+	hs.incremental = true
+	return hs
+}
+
+func (hs *DynamicHashSet[K]) startMigration(newSize int) {
+	hs.finishMigration()
+	hs.oldSlots, hs.oldCount, hs.migrated = hs.slots, hs.count, 0
+	hs.slots = make([]Slot[K], newSize)
+	hs.tombstones = 0
+	hs.loadSize = loadSizeFor(newSize)
+}
+
+func (hs *DynamicHashSet[K]) finishMigration() {
+	hs.migrate(len(hs.oldSlots))
+}
+
+func (hs *DynamicHashSet[K]) migrate(steps int) {
+	for ; steps > 0 && hs.oldSlots != nil; steps-- {
+		e := &hs.oldSlots[hs.migrated]
+
+		if !e.isEmpty() && !e.isTombstone() {
+			hash, seed, step := hs.hashKey(e.key)
+			idx, _ := seekFreeSlot(hs.slots, e.key, hash, seed, step)
+
+			if hs.slots[idx].isTombstone() {
+				hs.tombstones--
+			}
+
+			occupySlot(hs.slots, idx, e.key, hash)
+			e.state = slotTombstone
+			hs.oldCount--
 		}
 
-		idx = (idx + step) % len(hs.slots)
+		hs.migrated++
+
+		if hs.migrated == len(hs.oldSlots) {
+			hs.oldSlots = nil
+		}
 	}
+}
 
-	if firstDeletedSlot != -1 {
-		hs.insertAt(firstDeletedSlot, key, hash)
-		return nil
+// index of the key in the old table or -1, also when nothing is being migrated
+func (hs *DynamicHashSet[K]) findOld(key K) int {
+	if hs.oldSlots == nil {
+		return -1
 	}
 
-	return errors.New("table full")
+	hash, seed, step := probeStart(hs.hasher(key), len(hs.oldSlots))
+	return findSlot(hs.oldSlots, key, hash, seed, step)
 }
 
-func (hs *DynamicHashSet[K]) Find(key K) bool {
-	hash, seed, step := hs.hashKey(key)
+func (hs *DynamicHashSet[K]) deleteOld(key K) error {
+	idx := hs.findOld(key)
+
+	if idx == -1 {
+		return errors.New("no element found")
+	}
+
+	hs.oldSlots[idx].state = slotTombstone
+	hs.oldCount--
+	hs.count--
+	hs.version++
+	return nil
+}
+
+/*
+* The probing itself does not depend on what else we store next to the key, so it works on the plain slots slice and returns
+* indexes. This way the set and the map from below share the same collision bit, tombstone and first deleted slot logic,
+* the map just keeps its values in a parallel slice like NativeDictionary does.
+*
+* One thing I fixed while moving it here - when a tombstone is reused we must keep its collision bit. Otherwise keys which
+* were inserted after the deleted one and walked over this slot become unreachable, because Find stops at a slot without it.
+*/
+
+func probeStart(hashCode int, size int) (hash int, seed int, step int) {
+	hash = extractHash(hashCode)
+	seed = hash % size
+	step = 1 + (hash>>5)%(size-1)
+	return
+}
+
+// index of the slot holding the key or -1
+func findSlot[K comparable](slots []Slot[K], key K, hash, seed, step int) int {
 	idx := seed
 
-	for i := 0; i < len(hs.slots); i++ {
-		e := &hs.slots[idx]
+	for i := 0; i < len(slots); i++ {
+		e := &slots[idx]
 
 		if e.isEmpty() {
-			return false
+			return -1
 		}
 
 		if e.matchesKey(hash, key) {
-			return true
+			return idx
 		}
 
 		if !hasCollisionBit(e.hashColl) {
-			return false
+			return -1
 		}
 
-		idx = (idx + step) % len(hs.slots)
+		idx = (idx + step) % len(slots)
 	}
 
-	return false
+	return -1
 }
 
-func (hs *DynamicHashSet[K]) Delete(key K) error {
-	hash, seed, step := hs.hashKey(key)
-	idx := seed
+// index of the slot where the key should go, the first tombstone on the way is preferred. found is true when the key is already
+// at the returned index and -1 means that the table is full. Every occupied slot we walk over gets the collision bit
+func seekFreeSlot[K comparable](slots []Slot[K], key K, hash, seed, step int) (idx int, found bool) {
+	idx = seed
+	firstDeletedSlot := -1
 
-	for i := 0; i < len(hs.slots); i++ {
-		e := &hs.slots[idx]
+	for i := 0; i < len(slots); i++ {
+		currentSlot := &slots[idx]
 
-		if e.isEmpty() {
-			return errors.New("no element found")
+		if firstDeletedSlot == -1 && currentSlot.isTombstone() {
+			firstDeletedSlot = idx
 		}
 
-		if e.matchesKey(hash, key) {
-			hs.slots[idx].deleted = true
-			hs.count--
-			return nil
+		hasFreeSlotBehind := currentSlot.isEmpty() && firstDeletedSlot != -1
+		if hasFreeSlotBehind {
+			return firstDeletedSlot, false
+		} else if currentSlot.isEmpty() {
+			return idx, false
 		}
 
-		if !hasCollisionBit(e.hashColl) {
-			return errors.New("no element found")
+		if currentSlot.matchesKey(hash, key) {
+			return idx, true
+		}
+
+		if !hasCollisionBit(currentSlot.hashColl) {
+			currentSlot.hashColl |= CollisionBit
 		}
 
-		idx = (idx + step) % len(hs.slots)
+		idx = (idx + step) % len(slots)
 	}
 
-	return errors.New("no element found")
+	return firstDeletedSlot, false
 }
 
-func (hs *DynamicHashSet[K]) Count() int {
-	return hs.count
+func occupySlot[K comparable](slots []Slot[K], idx int, key K, hash int) {
+	slots[idx].key = key
+	slots[idx].hashColl = hash | (slots[idx].hashColl & CollisionBit)
+	slots[idx].state = slotOccupied
 }
 
-func (hs *DynamicHashSet[K]) hashKey(key K) (hash int, seed int, step int) {
-	h := key.HashCode()
-	hash = extractHash(h)
-	seed = hash % len(hs.slots)
-	step = 1 + (hash>>5)%(len(hs.slots)-1)
-	return
+/*
+* 8. Hash Table - task number 3 + 4 continuation - prime sizes
+*
+* Finally took the HashHelpers from .NET as well. The step from hashKey is always in [1, size - 1], so if the size is prime then
+* step and size are coprime and the probe sequence seed, seed + step, seed + 2 * step ... visits every slot before coming back
+* to the seed. With doubling the size was even after the first resize and a step like 2 would visit only half of the table, so
+* Insert could report "table full" while half of the slots were free. Same as in .NET we keep a table of primes which grow
+* roughly by 1.2 and for everything outside of it we search the next prime with trial division by odd numbers. The (i - 1) % hashPrime
+* check is also from there - such sizes would make their own fallback hash function degenerate, we do not need it with our
+* step function, but the table stays comparable with theirs. expandPrime doubles and then rounds up to the next prime.
+*/
+
+const hashPrime = 101
+
+var primes = [...]int{
+	3, 7, 11, 17, 23, 29, 37, 47, 59, 71, 89, 107, 131, 163, 197, 239, 293, 353, 431, 521, 631, 761, 919,
+	1103, 1327, 1597, 1931, 2333, 2801, 3371, 4049, 4861, 5839, 7013, 8419, 10103, 12143, 14591,
+	17519, 21023, 25229, 30293, 36353, 43627, 52361, 62851, 75431, 90523, 108631, 130363, 156437,
+	187751, 225307, 270371, 324449, 389357, 467237, 560689, 672827, 807403, 968897, 1162687, 1395263,
+	1674319, 2009191, 2411033, 2893249, 3471899, 4166287, 4999559, 5999471, 7199369,
 }
 
-func (hs *DynamicHashSet[K]) insertAt(idx int, key K, hash int) {
-	hs.slots[idx].key = key
-	hs.slots[idx].hashColl = hash
-	hs.slots[idx].deleted = false
-	hs.count++
+func isPrime(candidate int) bool {
+	if candidate&1 == 0 {
+		return candidate == 2
+	}
+
+	for divisor := 3; divisor*divisor <= candidate; divisor += 2 {
+		if candidate%divisor == 0 {
+			return false
+		}
+	}
+
+	return candidate > 1
 }
 
-func (hs *DynamicHashSet[K]) resize(newSize int) {
-	oldEntries := hs.slots
-	hs.slots = make([]Slot[K], newSize)
-	hs.count = 0
-	hs.loadSize = int(LoadFactor * float64(newSize))
+func getPrime(min int) int {
+	for _, prime := range primes {
+		if prime >= min {
+			return prime
+		}
+	}
 
-	for i := 0; i < len(oldEntries); i++ {
-		e := &oldEntries[i]
-		shouldReinsert := !e.isEmpty() && !e.isTombstone()
-		if shouldReinsert {
-			hs.Insert(e.key)
+	for i := min | 1; ; i += 2 {
+		if isPrime(i) && (i-1)%hashPrime != 0 {
+			return i
 		}
 	}
 }
 
+func expandPrime(oldSize int) int {
+	return getPrime(2 * oldSize)
+}
+
+func loadSizeFor(size int) int {
+	return int(LoadFactor * float64(size))
+}
+
 func extractHash(hashColl int) int {
 	return hashColl & HashMask
 }
 
+// int is 64 bit on most platforms, so the bit 31 does not make the value negative like the int32 in C# does
 func hasCollisionBit(hashColl int) bool {
-	return hashColl < 0
+	return hashColl&CollisionBit != 0
 }
 
 func (e *Slot[K]) isEmpty() bool {
-	var zero K
-	return e.key == zero && !e.deleted
+	return e.state == slotEmpty
 }
 
 func (e *Slot[K]) isTombstone() bool {
-	return e.deleted
+	return e.state == slotTombstone
 }
 
 func (e *Slot[K]) matchesKey(hash int, key K) bool {
-	return extractHash(e.hashColl) == hash && e.key == key && !e.deleted
+	return e.state == slotOccupied && extractHash(e.hashColl) == hash && e.key == key
 }
 
 /*
@@ -221,27 +548,987 @@ func (e *Slot[K]) matchesKey(hash int, key K) bool {
 * basically zero (one XOR instruction), but security improvement is huge. Modern languages do this by default now.
 */
 
+// the salted set is just the plain one whose hasher mixes the salt in, so Insert, Find, Delete and resize are shared
 type DynamicHashSetSalt[K Hashable] struct {
+	*DynamicHashSet[K]
+	salt uint32
+}
+
+func NewDynamicHashSetSalt[K Hashable]() *DynamicHashSetSalt[K] {
+	salt := rand.New(rand.NewSource(time.Now().UnixNano())).Uint32()
+	return &DynamicHashSetSalt[K]{
+		DynamicHashSet: NewDynamicHashSetFunc(func(key K) int { return key.HashCode() ^ int(salt) }),
+		salt:           salt,
+	}
+}
+
+/*
+* 8. Hash Table - key value map on top of the set slots
+*
+* Same open addressing as in DynamicHashSet - double hashing over prime sizes, collision bit, tombstones and reuse of the first
+* deleted slot - all through the shared findSlot and seekFreeSlot. The values live in a parallel slice with the same length as
+* slots, so the index returned by probing addresses both. Put on an existing key only overwrites the value, which is also why it
+* does not return an error like Insert of the set does - and why it does not bump the version, the keys stay the same and a running
+* iteration is still valid. On Delete we zero the value so the map does not keep it alive.
+*/
+
+type DynamicHashMap[K comparable, V any] struct {
 	slots    []Slot[K]
+	values   []V
 	count    int
 	loadSize int
-	salt     uint32
+	version  int
+	hasher   func(K) int
 }
 
-func NewDynamicHashSetSalt[K Hashable]() *DynamicHashSetSalt[K] {
-	return &DynamicHashSetSalt[K]{
-		slots:    make([]Slot[K], InitialSize),
+func NewDynamicHashMap[K Hashable, V any]() *DynamicHashMap[K, V] {
+	return NewDynamicHashMapFunc[K, V](func(key K) int { return key.HashCode() })
+}
+
+func NewDynamicHashMapFunc[K comparable, V any](hasher func(K) int) *DynamicHashMap[K, V] {
+	size := getPrime(InitialSize)
+	return &DynamicHashMap[K, V]{
+		slots:    make([]Slot[K], size),
+		values:   make([]V, size),
 		count:    0,
-		loadSize: int(LoadFactor * float64(InitialSize)),
-		salt:     rand.New(rand.NewSource(time.Now().UnixNano())).Uint32(),
+		loadSize: loadSizeFor(size),
+		hasher:   hasher,
 	}
 }
 
-func (hs *DynamicHashSetSalt[K]) hashKey(key K) (hash int, seed int, step int) {
-	h := key.HashCode()
-	h = h ^ int(hs.salt)
-	hash = extractHash(h)
-	seed = hash % len(hs.slots)
-	step = 1 + (hash>>5)%(len(hs.slots)-1)
-	return
-}
\ No newline at end of file
+func (hm *DynamicHashMap[K, V]) Put(key K, value V) {
+	hash, seed, step := hm.hashKey(key)
+
+	if idx := findSlot(hm.slots, key, hash, seed, step); idx != -1 {
+		hm.values[idx] = value
+		return
+	}
+
+	if hm.count >= hm.loadSize {
+		hm.resize(expandPrime(len(hm.slots)))
+		hash, seed, step = hm.hashKey(key)
+	}
+
+	idx, _ := seekFreeSlot(hm.slots, key, hash, seed, step)
+	occupySlot(hm.slots, idx, key, hash)
+	hm.values[idx] = value
+	hm.count++
+	hm.version++
+}
+
+func (hm *DynamicHashMap[K, V]) Get(key K) (V, error) {
+	var result V
+	hash, seed, step := hm.hashKey(key)
+	idx := findSlot(hm.slots, key, hash, seed, step)
+
+	if idx == -1 {
+		return result, errors.New("key not found")
+	}
+
+	return hm.values[idx], nil
+}
+
+func (hm *DynamicHashMap[K, V]) IsKey(key K) bool {
+	hash, seed, step := hm.hashKey(key)
+	return findSlot(hm.slots, key, hash, seed, step) != -1
+}
+
+func (hm *DynamicHashMap[K, V]) Delete(key K) error {
+	hash, seed, step := hm.hashKey(key)
+	idx := findSlot(hm.slots, key, hash, seed, step)
+
+	if idx == -1 {
+		return errors.New("key not found")
+	}
+
+	var zero V
+	hm.slots[idx].state = slotTombstone
+	hm.values[idx] = zero
+	hm.count--
+	hm.version++
+	return nil
+}
+
+func (hm *DynamicHashMap[K, V]) Count() int {
+	return hm.count
+}
+
+// key value pairs in slot order, panics if a key is put or deleted before the iteration is over - overwriting a value is fine
+func (hm *DynamicHashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		version := hm.version
+		for i := range hm.slots {
+			e := &hm.slots[i]
+			if e.isEmpty() || e.isTombstone() {
+				continue
+			}
+			if !yield(e.key, hm.values[i]) {
+				return
+			}
+			if hm.version != version {
+				panic(errModifiedDuringIteration)
+			}
+		}
+	}
+}
+
+func (hm *DynamicHashMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range hm.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+func (hm *DynamicHashMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range hm.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+func (hm *DynamicHashMap[K, V]) hashKey(key K) (hash int, seed int, step int) {
+	return probeStart(hm.hasher(key), len(hm.slots))
+}
+
+func (hm *DynamicHashMap[K, V]) resize(newSize int) {
+	oldSlots, oldValues := hm.slots, hm.values
+	hm.slots = make([]Slot[K], newSize)
+	hm.values = make([]V, newSize)
+	hm.loadSize = loadSizeFor(newSize)
+
+	for i := range oldSlots {
+		e := &oldSlots[i]
+		if e.isEmpty() || e.isTombstone() {
+			continue
+		}
+		hash, seed, step := hm.hashKey(e.key)
+		idx, _ := seekFreeSlot(hm.slots, e.key, hash, seed, step)
+		occupySlot(hm.slots, idx, e.key, hash)
+		hm.values[idx] = oldValues[i]
+	}
+}
+
+/*
+* 8. Hash Table - ready made Hashable keys
+*
+* Writing a wrapper with HashCode for every string or int key gets boring very fast, so here are the common ones. All of them
+* hash with hash/maphash and one seed per process, which is random - so the hash codes and with them the iteration order differ
+* between runs, exactly what Go does with its own maps. BytesKey is a string underneath, because a []byte is not comparable and
+* could not be a key at all. ComparableKey covers everything else comparable through maphash.Comparable - structs, arrays,
+* pointers. And if the key type is not ours, NewDynamicHashSetFunc and NewDynamicHashMapFunc take the hash function directly,
+* so no wrapper is needed at all. For the Func variants the caller is responsible that equal keys produce equal hashes.
+*/
+
+var keySeed = maphash.MakeSeed()
+
+type StringKey string
+
+func (k StringKey) HashCode() int {
+	return int(maphash.String(keySeed, string(k)))
+}
+
+type IntKey int
+
+func (k IntKey) HashCode() int {
+	return int(maphash.Comparable(keySeed, k))
+}
+
+type BytesKey string
+
+func NewBytesKey(b []byte) BytesKey {
+	return BytesKey(b)
+}
+
+func (k BytesKey) Bytes() []byte {
+	return []byte(k)
+}
+
+func (k BytesKey) HashCode() int {
+	return int(maphash.String(keySeed, string(k)))
+}
+
+type ComparableKey[T comparable] struct {
+	Value T
+}
+
+func (k ComparableKey[T]) HashCode() int {
+	return int(maphash.Comparable(keySeed, k.Value))
+}
+
+/*
+* 8. Hash Table - set operations
+*
+* The lookup is O(1), so the cost of every operation is the number of keys we walk over - and we always walk the smaller set and
+* ask the bigger one. Union has to copy one of the sets anyway, so the bigger one is copied and the smaller one walked. Difference
+* and the symmetric difference have to walk the keys which may end up in the result, and they only insert the ones which survive -
+* copying and then deleting would let Delete shrink the table in the middle and the next inserts grow it again. The result table is
+* created big enough for the largest possible result right away, so no resize happens on the way. Union, intersection
+* and difference return a new set with the hash function of this set, the With variants change this set in place.
+*/
+
+func (hs *DynamicHashSet[K]) Union(other *DynamicHashSet[K]) *DynamicHashSet[K] {
+	larger, smaller := hs, other
+	if larger.count < smaller.count {
+		larger, smaller = smaller, larger
+	}
+
+	result := hs.copyWithCapacity(larger, hs.count+other.count)
+	for key := range smaller.All() {
+		result.Insert(key)
+	}
+
+	return result
+}
+
+func (hs *DynamicHashSet[K]) Intersect(other *DynamicHashSet[K]) *DynamicHashSet[K] {
+	smaller, larger := hs, other
+	if smaller.count > larger.count {
+		smaller, larger = larger, smaller
+	}
+
+	result := hs.withCapacity(smaller.count)
+	for key := range smaller.All() {
+		if larger.Find(key) {
+			result.Insert(key)
+		}
+	}
+
+	return result
+}
+
+// keys of this set which are not in the other one
+func (hs *DynamicHashSet[K]) Difference(other *DynamicHashSet[K]) *DynamicHashSet[K] {
+	result := hs.withCapacity(hs.count)
+	insertMissing(result, hs, other)
+	return result
+}
+
+func (hs *DynamicHashSet[K]) SymmetricDifference(other *DynamicHashSet[K]) *DynamicHashSet[K] {
+	result := hs.withCapacity(hs.count + other.count)
+	insertMissing(result, hs, other)
+	insertMissing(result, other, hs)
+	return result
+}
+
+// what insertMissing needs from a set, DynamicHashSet and SwissHashSet both have it
+type keySet[K comparable] interface {
+	Insert(key K) error
+	Find(key K) bool
+	All() iter.Seq[K]
+}
+
+// inserts the keys of source which are not in excluded
+func insertMissing[K comparable](result, source, excluded keySet[K]) {
+	for key := range source.All() {
+		if !excluded.Find(key) {
+			result.Insert(key)
+		}
+	}
+}
+
+// whether every key of this set is also in the other one
+func (hs *DynamicHashSet[K]) IsSubset(other *DynamicHashSet[K]) bool {
+	if hs.count > other.count {
+		return false
+	}
+
+	for key := range hs.All() {
+		if !other.Find(key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (hs *DynamicHashSet[K]) Equal(other *DynamicHashSet[K]) bool {
+	return hs.count == other.count && hs.IsSubset(other)
+}
+
+func (hs *DynamicHashSet[K]) UnionWith(other *DynamicHashSet[K]) {
+	hs.EnsureCapacity(hs.count + other.count)
+
+	for key := range other.All() {
+		hs.Insert(key)
+	}
+}
+
+// when the other set is smaller we collect the common keys from it and take over the new table, otherwise the keys which are
+// not in the other set are deleted right in the slots - All would panic on that
+func (hs *DynamicHashSet[K]) IntersectWith(other *DynamicHashSet[K]) {
+	hs.finishMigration()
+
+	if other.count < hs.count {
+		result := hs.Intersect(other)
+		result.version = hs.version + 1
+		*hs = *result
+		return
+	}
+
+	for i := range hs.slots {
+		e := &hs.slots[i]
+		if e.isEmpty() || e.isTombstone() || other.Find(e.key) {
+			continue
+		}
+		e.state = slotTombstone
+		hs.count--
+		hs.tombstones++
+		hs.version++
+	}
+
+	hs.compact()
+}
+
+// empty set with the hash function of this one and enough slots for capacity keys without a resize
+func (hs *DynamicHashSet[K]) withCapacity(capacity int) *DynamicHashSet[K] {
+	size := sizeForCapacity(capacity)
+	return &DynamicHashSet[K]{
+		slots:       make([]Slot[K], size),
+		count:       0,
+		loadSize:    loadSizeFor(size),
+		hasher:      hs.hasher,
+		incremental: hs.incremental,
+	}
+}
+
+func (hs *DynamicHashSet[K]) copyWithCapacity(source *DynamicHashSet[K], capacity int) *DynamicHashSet[K] {
+	result := hs.withCapacity(capacity)
+	for key := range source.All() {
+		result.Insert(key)
+	}
+	return result
+}
+
+// smallest prime size whose load size is at least the capacity
+func sizeForCapacity(capacity int) int {
+	return getPrime(max(InitialSize, int(float64(capacity)/LoadFactor)+1))
+}
+
+/*
+* 8. Hash Table - swiss table
+*
+* The double hashing above keeps the whole Slot per bucket and every probe step jumps to a new place in the table, compares the
+* hash and then the key. For big sets almost every step is a cache miss. The swiss table (abseil, and since 1.24 the Go map itself)
+* splits the metadata from the keys: one control byte per slot, 0x80 for empty, 0xFE for deleted and 0b0xxxxxxx with the lower
+* 7 bits of the hash for a used slot. Eight control bytes make a group which is exactly one uint64, so we do not look at slots
+* one by one but at the whole group with a few bit tricks (SWAR - SIMD within a register): which bytes are equal to our 7 bits,
+* which are empty. Only the candidates from the mask are compared by key, with 7 bits that is one false positive per 128 slots.
+*
+* The rest of the hash picks the first group, the groups are probed with growing steps 1, 2, 3... - with the power of two number
+* of groups these triangular numbers visit every group. The search stops at the first group with an empty byte. Thanks to that
+* a deleted slot can become empty right away if its group still has an empty byte - no probe ever went past this group. Only in
+* a full group we need a tombstone, they are counted as before and a table full of tombstones is rehashed in place instead of
+* growing. HashCode of our keys is not necessarily well mixed (the int keys in the tests are just multiplied by 31), and here the
+* low bits decide the group and the 7 bits at the same time, so the hash code goes through the murmur3 finalizer first.
+*/
+
+const (
+	groupSize          = 8
+	swissInitialGroups = 2
+	ctrlEmpty          = 0x80
+	ctrlDeleted        = 0xFE
+	lowBits            = 0x0101010101010101
+	highBits           = 0x8080808080808080
+)
+
+type SwissHashSet[K comparable] struct {
+	ctrl       []uint64
+	keys       []K
+	count      int
+	tombstones int
+	loadSize   int
+	version    int
+	hasher     func(K) int
+}
+
+func NewSwissHashSet[K Hashable]() *SwissHashSet[K] {
+	return NewSwissHashSetFunc(func(key K) int { return key.HashCode() })
+}
+
+func NewSwissHashSetFunc[K comparable](hasher func(K) int) *SwissHashSet[K] {
+	hs := &SwissHashSet[K]{hasher: hasher}
+	hs.allocate(swissInitialGroups)
+	return hs
+}
+
+func (hs *SwissHashSet[K]) Insert(key K) error {
+	groupHash, fragment := hs.hashKey(key)
+
+	if hs.findIndex(groupHash, fragment, key) != -1 {
+		return errors.New("duplicate key")
+	}
+
+	if hs.count+hs.tombstones >= hs.loadSize {
+		hs.makeRoom()
+	}
+
+	idx := hs.freeIndex(groupHash)
+
+	if hs.control(idx) == ctrlDeleted {
+		hs.tombstones--
+	}
+
+	hs.setControl(idx, fragment)
+	hs.keys[idx] = key
+	hs.count++
+	hs.version++
+	return nil
+}
+
+func (hs *SwissHashSet[K]) Find(key K) bool {
+	groupHash, fragment := hs.hashKey(key)
+	return hs.findIndex(groupHash, fragment, key) != -1
+}
+
+func (hs *SwissHashSet[K]) Delete(key K) error {
+	groupHash, fragment := hs.hashKey(key)
+	idx := hs.findIndex(groupHash, fragment, key)
+
+	if idx == -1 {
+		return errors.New("no element found")
+	}
+
+	hs.removeAt(idx)
+	return nil
+}
+
+func (hs *SwissHashSet[K]) removeAt(idx int) {
+	var zero K
+	hs.keys[idx] = zero
+
+	if matchEmpty(hs.ctrl[idx/groupSize]) != 0 {
+		hs.setControl(idx, ctrlEmpty)
+	} else {
+		hs.setControl(idx, ctrlDeleted)
+		hs.tombstones++
+	}
+
+	hs.count--
+	hs.version++
+}
+
+func (hs *SwissHashSet[K]) Count() int {
+	return hs.count
+}
+
+func (hs *SwissHashSet[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		version := hs.version
+		for g, group := range hs.ctrl {
+			for full := matchFull(group); full != 0; full &= full - 1 {
+				if !yield(hs.keys[g*groupSize+firstMatch(full)]) {
+					return
+				}
+				if hs.version != version {
+					panic(errModifiedDuringIteration)
+				}
+			}
+		}
+	}
+}
+
+// the upper 57 bits choose the first group, the lower 7 bits go to the control byte
+func (hs *SwissHashSet[K]) hashKey(key K) (groupHash uint64, fragment uint8) {
+	h := mix64(uint64(hs.hasher(key)))
+	return h >> 7, uint8(h & 0x7F)
+}
+
+// murmur3 finalizer - every input bit changes about half of the output bits
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// index of the slot holding the key or -1
+func (hs *SwissHashSet[K]) findIndex(groupHash uint64, fragment uint8, key K) int {
+	mask := uint64(len(hs.ctrl) - 1)
+	g := groupHash & mask
+
+	for step := uint64(1); ; step++ {
+		group := hs.ctrl[g]
+
+		for match := matchByte(group, fragment); match != 0; match &= match - 1 {
+			idx := int(g)*groupSize + firstMatch(match)
+			if hs.keys[idx] == key {
+				return idx
+			}
+		}
+
+		if matchEmpty(group) != 0 {
+			return -1
+		}
+
+		g = (g + step) & mask
+	}
+}
+
+// first empty or deleted slot on the probe sequence, the load factor guarantees there is one
+func (hs *SwissHashSet[K]) freeIndex(groupHash uint64) int {
+	mask := uint64(len(hs.ctrl) - 1)
+	g := groupHash & mask
+
+	for step := uint64(1); ; step++ {
+		if free := matchEmptyOrDeleted(hs.ctrl[g]); free != 0 {
+			return int(g)*groupSize + firstMatch(free)
+		}
+
+		g = (g + step) & mask
+	}
+}
+
+// mostly tombstones - same number of groups is enough, otherwise double
+func (hs *SwissHashSet[K]) makeRoom() {
+	if hs.count <= hs.loadSize/2 {
+		hs.resize(len(hs.ctrl))
+		return
+	}
+
+	hs.resize(2 * len(hs.ctrl))
+}
+
+func (hs *SwissHashSet[K]) resize(groups int) {
+	oldCtrl, oldKeys := hs.ctrl, hs.keys
+	hs.allocate(groups)
+	hs.version++
+
+	for g, group := range oldCtrl {
+		for full := matchFull(group); full != 0; full &= full - 1 {
+			key := oldKeys[g*groupSize+firstMatch(full)]
+			groupHash, fragment := hs.hashKey(key)
+			idx := hs.freeIndex(groupHash)
+			hs.setControl(idx, fragment)
+			hs.keys[idx] = key
+			hs.count++
+		}
+	}
+}
+
+// at most 7 of 8 slots are used, so every probe sequence meets an empty byte
+func (hs *SwissHashSet[K]) allocate(groups int) {
+	hs.ctrl = make([]uint64, groups)
+	for i := range hs.ctrl {
+		hs.ctrl[i] = lowBits * ctrlEmpty
+	}
+	hs.keys = make([]K, groups*groupSize)
+	hs.count = 0
+	hs.tombstones = 0
+	hs.loadSize = groups * groupSize * 7 / 8
+}
+
+func (hs *SwissHashSet[K]) control(idx int) uint8 {
+	return uint8(hs.ctrl[idx/groupSize] >> (idx % groupSize * 8))
+}
+
+func (hs *SwissHashSet[K]) setControl(idx int, value uint8) {
+	shift := idx % groupSize * 8
+	group := &hs.ctrl[idx/groupSize]
+	*group = *group&^(0xFF<<shift) | uint64(value)<<shift
+}
+
+/*
+* The bit tricks. Every function returns a mask with the highest bit set in the bytes which match, firstMatch turns the lowest
+* of them into the slot index and mask &= mask - 1 drops it. matchByte xors the group with the fragment in every byte, so the
+* matching bytes become zero, and then uses the classic "has zero byte" trick. It can report a false positive in a byte right
+* above a real match, but never in an empty or deleted byte (their highest bit stays set after the xor) - and the keys are
+* compared anyway. Empty is the only control byte with the highest bit set and the second lowest bit not set.
+*/
+
+func matchByte(group uint64, fragment uint8) uint64 {
+	x := group ^ (lowBits * uint64(fragment))
+	return (x - lowBits) &^ x & highBits
+}
+
+func matchEmpty(group uint64) uint64 {
+	return group &^ (group << 6) & highBits
+}
+
+func matchEmptyOrDeleted(group uint64) uint64 {
+	return group & highBits
+}
+
+func matchFull(group uint64) uint64 {
+	return ^group & highBits
+}
+
+func firstMatch(mask uint64) int {
+	return bits.TrailingZeros64(mask) / 8
+}
+
+/*
+* The rest of the DynamicHashSet surface. Capacity is again the number of keys which fit before the next resize, here 7 per group
+* with the number of groups a power of two. The set operations are the same walks as for DynamicHashSet - the smaller set is
+* walked and the bigger one asked, and the result is presized for the largest possible result so it never resizes on the way.
+*/
+
+// shrinks the table to the smallest number of groups which holds the current keys, tombstones are dropped on the way
+func (hs *SwissHashSet[K]) TrimExcess() {
+	hs.resize(groupsForCapacity(hs.count))
+}
+
+// makes sure that capacity keys fit without a resize and returns the new capacity
+func (hs *SwissHashSet[K]) EnsureCapacity(capacity int) int {
+	if capacity > hs.loadSize {
+		hs.resize(groupsForCapacity(capacity))
+	}
+	return hs.loadSize
+}
+
+func (hs *SwissHashSet[K]) Union(other *SwissHashSet[K]) *SwissHashSet[K] {
+	larger, smaller := hs, other
+	if larger.count < smaller.count {
+		larger, smaller = smaller, larger
+	}
+
+	result := hs.copyWithCapacity(larger, hs.count+other.count)
+	for key := range smaller.All() {
+		result.Insert(key)
+	}
+
+	return result
+}
+
+func (hs *SwissHashSet[K]) Intersect(other *SwissHashSet[K]) *SwissHashSet[K] {
+	smaller, larger := hs, other
+	if smaller.count > larger.count {
+		smaller, larger = larger, smaller
+	}
+
+	result := hs.withCapacity(smaller.count)
+	for key := range smaller.All() {
+		if larger.Find(key) {
+			result.Insert(key)
+		}
+	}
+
+	return result
+}
+
+// keys of this set which are not in the other one
+func (hs *SwissHashSet[K]) Difference(other *SwissHashSet[K]) *SwissHashSet[K] {
+	result := hs.withCapacity(hs.count)
+	insertMissing(result, hs, other)
+	return result
+}
+
+func (hs *SwissHashSet[K]) SymmetricDifference(other *SwissHashSet[K]) *SwissHashSet[K] {
+	result := hs.withCapacity(hs.count + other.count)
+	insertMissing(result, hs, other)
+	insertMissing(result, other, hs)
+	return result
+}
+
+// whether every key of this set is also in the other one
+func (hs *SwissHashSet[K]) IsSubset(other *SwissHashSet[K]) bool {
+	if hs.count > other.count {
+		return false
+	}
+
+	for key := range hs.All() {
+		if !other.Find(key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (hs *SwissHashSet[K]) Equal(other *SwissHashSet[K]) bool {
+	return hs.count == other.count && hs.IsSubset(other)
+}
+
+func (hs *SwissHashSet[K]) UnionWith(other *SwissHashSet[K]) {
+	hs.EnsureCapacity(hs.count + other.count)
+
+	for key := range other.All() {
+		hs.Insert(key)
+	}
+}
+
+// same as for DynamicHashSet - take over the intersection when the other set is smaller, otherwise remove right in the slots
+func (hs *SwissHashSet[K]) IntersectWith(other *SwissHashSet[K]) {
+	if other.count < hs.count {
+		result := hs.Intersect(other)
+		result.version = hs.version + 1
+		*hs = *result
+		return
+	}
+
+	for g := range hs.ctrl {
+		for full := matchFull(hs.ctrl[g]); full != 0; full &= full - 1 {
+			idx := g*groupSize + firstMatch(full)
+			if !other.Find(hs.keys[idx]) {
+				hs.removeAt(idx)
+			}
+		}
+	}
+}
+
+// empty set with the hash function of this one and enough groups for capacity keys without a resize
+func (hs *SwissHashSet[K]) withCapacity(capacity int) *SwissHashSet[K] {
+	result := &SwissHashSet[K]{hasher: hs.hasher}
+	result.allocate(groupsForCapacity(capacity))
+	return result
+}
+
+func (hs *SwissHashSet[K]) copyWithCapacity(source *SwissHashSet[K], capacity int) *SwissHashSet[K] {
+	result := hs.withCapacity(capacity)
+	for key := range source.All() {
+		result.Insert(key)
+	}
+	return result
+}
+
+// smallest power of two number of groups whose load size is at least the capacity
+func groupsForCapacity(capacity int) int {
+	groups := swissInitialGroups
+	for groups*groupSize*7/8 < capacity {
+		groups *= 2
+	}
+	return groups
+}
+
+/*
+* 8. Hash Table - cuckoo hashing
+*
+* All the sets above probe until they find the key or an empty slot, so in the worst case the lookup walks a long chain. Cuckoo
+* hashing turns it around - a key can only be in two places, its slot in the first table or its slot in the second one, each table
+* with its own hash function. So Find and Delete look at exactly two slots. The work moves to Insert: if both slots are taken, the
+* new key kicks out the key from the first table, that one moves to its slot in the second table, kicking out the key there, and
+* so on. The chain is bounded by cuckooMaxEvictions, if it is longer we are most likely in a cycle. Then every move is undone and
+* the key goes to the stash - a few extra slots which Find checks after the two tables, still constant time. Only when the stash
+* is full too the whole set is rebuilt with new hash functions, and if that does not help a few times, with twice bigger tables.
+*
+* The two hash functions are the HashCode mixed with two random seeds, new seeds are new functions. Two choices per key only work
+* well under half load, so the tables grow at CuckooLoadFactor. If the hash codes themselves collide no seed helps - keys with
+* the same HashCode have the same two slots - so after cuckooMaxRehashes failed rebuilds Insert gives up with an error and the set
+* stays as it was.
+*/
+
+const (
+	CuckooLoadFactor   = 0.45
+	cuckooInitialSize  = 16
+	cuckooStashSize    = 4
+	cuckooMaxEvictions = 32
+	cuckooMaxRehashes  = 8
+)
+
+type cuckooSlot[K comparable] struct {
+	key  K
+	used bool
+}
+
+type CuckooHashSet[K comparable] struct {
+	tables   [2][]cuckooSlot[K]
+	seeds    [2]uint64
+	stash    []K
+	count    int
+	loadSize int
+	version  int
+	hasher   func(K) int
+	random   *rand.Rand
+}
+
+func NewCuckooHashSet[K Hashable]() *CuckooHashSet[K] {
+	return NewCuckooHashSetFunc(func(key K) int { return key.HashCode() })
+}
+
+func NewCuckooHashSetFunc[K comparable](hasher func(K) int) *CuckooHashSet[K] {
+	hs := &CuckooHashSet[K]{
+		hasher: hasher,
+		random: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	hs.allocate(cuckooInitialSize)
+	return hs
+}
+
+func (hs *CuckooHashSet[K]) Insert(key K) error {
+	if hs.Find(key) {
+		return errors.New("duplicate key")
+	}
+
+	if hs.count < hs.loadSize && (hs.place(key) || hs.pushStash(key)) {
+		hs.count++
+		hs.version++
+		return nil
+	}
+
+	size := len(hs.tables[0])
+	if hs.count >= hs.loadSize {
+		size *= 2
+	}
+
+	if !hs.rebuild(size, append(slices.Collect(hs.All()), key)) {
+		return errors.New("too many keys with colliding hash codes")
+	}
+
+	hs.version++
+	return nil
+}
+
+func (hs *CuckooHashSet[K]) Find(key K) bool {
+	code := hs.hasher(key)
+	for t := range hs.tables {
+		slot := &hs.tables[t][hs.index(t, code)]
+		if slot.used && slot.key == key {
+			return true
+		}
+	}
+	return slices.Contains(hs.stash, key)
+}
+
+func (hs *CuckooHashSet[K]) Delete(key K) error {
+	code := hs.hasher(key)
+	found := false
+
+	for t := range hs.tables {
+		slot := &hs.tables[t][hs.index(t, code)]
+		if slot.used && slot.key == key {
+			*slot = cuckooSlot[K]{}
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		idx := slices.Index(hs.stash, key)
+		if idx == -1 {
+			return errors.New("no element found")
+		}
+		hs.stash = slices.Delete(hs.stash, idx, idx+1)
+	}
+
+	hs.count--
+	hs.version++
+	return nil
+}
+
+func (hs *CuckooHashSet[K]) Count() int {
+	return hs.count
+}
+
+func (hs *CuckooHashSet[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		version := hs.version
+		for t := range hs.tables {
+			for i := range hs.tables[t] {
+				if !hs.tables[t][i].used {
+					continue
+				}
+				if !yield(hs.tables[t][i].key) {
+					return
+				}
+				if hs.version != version {
+					panic(errModifiedDuringIteration)
+				}
+			}
+		}
+		for _, key := range hs.stash {
+			if !yield(key) {
+				return
+			}
+			if hs.version != version {
+				panic(errModifiedDuringIteration)
+			}
+		}
+	}
+}
+
+// the two slots the key can be in, first table and second table
+func (hs *CuckooHashSet[K]) positions(key K) (int, int) {
+	code := hs.hasher(key)
+	return hs.index(0, code), hs.index(1, code)
+}
+
+func (hs *CuckooHashSet[K]) index(table int, code int) int {
+	return int(mix64(uint64(code)^hs.seeds[table]) & uint64(len(hs.tables[table])-1))
+}
+
+// the eviction chain, on failure every evicted key is moved back, so the tables are exactly as before
+func (hs *CuckooHashSet[K]) place(key K) bool {
+	first, second := hs.positions(key)
+
+	if hs.tables[0][first].used && !hs.tables[1][second].used {
+		hs.tables[1][second] = cuckooSlot[K]{key: key, used: true}
+		return true
+	}
+
+	var path [cuckooMaxEvictions]int
+	current := key
+
+	for i := range path {
+		t := i % 2
+		path[i] = hs.index(t, hs.hasher(current))
+		slot := &hs.tables[t][path[i]]
+
+		if !slot.used {
+			*slot = cuckooSlot[K]{key: current, used: true}
+			return true
+		}
+
+		current, slot.key = slot.key, current
+	}
+
+	for i := len(path) - 1; i >= 0; i-- {
+		slot := &hs.tables[i%2][path[i]]
+		current, slot.key = slot.key, current
+	}
+
+	return false
+}
+
+func (hs *CuckooHashSet[K]) pushStash(key K) bool {
+	if len(hs.stash) == cuckooStashSize {
+		return false
+	}
+
+	hs.stash = append(hs.stash, key)
+	return true
+}
+
+// new seeds until all the keys fit, half of the attempts with the given size and then with the double one.
+// The set is replaced only on success
+func (hs *CuckooHashSet[K]) rebuild(size int, keys []K) bool {
+	for attempt := 0; attempt < cuckooMaxRehashes; attempt++ {
+		if attempt == cuckooMaxRehashes/2 {
+			size *= 2
+		}
+
+		candidate := &CuckooHashSet[K]{hasher: hs.hasher, random: hs.random, version: hs.version}
+		candidate.allocate(size)
+
+		placed := true
+		for _, key := range keys {
+			if !candidate.place(key) && !candidate.pushStash(key) {
+				placed = false
+				break
+			}
+		}
+
+		if placed {
+			candidate.count = len(keys)
+			*hs = *candidate
+			return true
+		}
+	}
+
+	return false
+}
+
+// size is the length of one table and a power of two
+func (hs *CuckooHashSet[K]) allocate(size int) {
+	hs.tables = [2][]cuckooSlot[K]{make([]cuckooSlot[K], size), make([]cuckooSlot[K], size)}
+	hs.seeds = [2]uint64{hs.random.Uint64(), hs.random.Uint64()}
+	hs.stash = nil
+	hs.count = 0
+	hs.loadSize = int(CuckooLoadFactor * float64(2*size))
+}
@@ -1,7 +1,12 @@
 package hashtable
 
 import (
+	"fmt"
+	"math/big"
+	"math/rand"
+
 	"github.com/stretchr/testify/assert"
+	"slices"
 	"testing"
 )
 
@@ -296,4 +301,2113 @@ func Test_GivenHashTable_WhenInsertingWithCollisions_ThenHandlesCollisionsCorrec
 	assert.NotEqual(t, -1, ht.Find("a"))
 	assert.NotEqual(t, -1, ht.Find("f"))
 	assert.NotEqual(t, -1, ht.Find("k"))
-}
\ No newline at end of file
+}
+
+// DYNAMIC HASH SET - ALL
+
+type intKey int
+
+func (k intKey) HashCode() int {
+	return int(k) * 31
+}
+
+func Test_GivenSetWithKeys_WhenRangingOverAll_ThenEveryKeyYieldedOnce(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+	for i := 1; i <= 40; i++ {
+		hs.Insert(intKey(i))
+	}
+
+	// When
+	keys := slices.Collect(hs.All())
+
+	// Then
+	slices.Sort(keys)
+	assert.Len(t, keys, 40)
+	for i, k := range keys {
+		assert.Equal(t, intKey(i+1), k)
+	}
+	assert.Equal(t, 40, hs.Count())
+}
+
+func Test_GivenSetWithDeletedKeys_WhenRangingOverAll_ThenTombstonesSkipped(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+	hs.Insert(1)
+	hs.Insert(2)
+	hs.Insert(3)
+	hs.Delete(2)
+
+	// When
+	keys := slices.Collect(hs.All())
+
+	// Then
+	slices.Sort(keys)
+	assert.Equal(t, []intKey{1, 3}, keys)
+}
+
+func Test_GivenEmptySet_WhenRangingOverAll_ThenNothingYielded(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+
+	// When
+	keys := slices.Collect(hs.All())
+
+	// Then
+	assert.Empty(t, keys)
+}
+
+// DYNAMIC HASH SET - MODIFICATION DURING ITERATION
+
+func Test_GivenIteration_WhenInsertingNewKey_ThenPanics(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+	hs.Insert(1)
+	hs.Insert(2)
+
+	// When/Then
+	assert.PanicsWithError(t, errModifiedDuringIteration.Error(), func() {
+		for range hs.All() {
+			hs.Insert(3)
+		}
+	})
+}
+
+func Test_GivenIteration_WhenDeletingKey_ThenPanics(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+	hs.Insert(1)
+	hs.Insert(2)
+
+	// When/Then
+	assert.PanicsWithError(t, errModifiedDuringIteration.Error(), func() {
+		for k := range hs.All() {
+			hs.Delete(k)
+		}
+	})
+}
+
+func Test_GivenIteration_WhenFailedInsertAndDelete_ThenIterationCompletes(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+	hs.Insert(1)
+	hs.Insert(2)
+	visited := 0
+
+	// When
+	for k := range hs.All() {
+		assert.Error(t, hs.Insert(k))
+		assert.Error(t, hs.Delete(100))
+		visited++
+	}
+
+	// Then
+	assert.Equal(t, 2, visited)
+}
+
+
+// DYNAMIC HASH SET - PRIME SIZES
+
+func Test_GivenValuesInsidePrimeTable_WhenGettingPrime_ThenSmallestTablePrimeReturned(t *testing.T) {
+	// Given/When/Then
+	assert.Equal(t, 17, getPrime(17))
+	assert.Equal(t, 23, getPrime(18))
+	assert.Equal(t, 3, getPrime(0))
+}
+
+func Test_GivenValueAboveTable_WhenGettingPrime_ThenNextSuitablePrimeReturned(t *testing.T) {
+	// Given
+	min := 7199370
+
+	// When
+	prime := getPrime(min)
+
+	// Then
+	assert.GreaterOrEqual(t, prime, min)
+	assert.True(t, isPrime(prime))
+	assert.NotEqual(t, 0, (prime-1)%hashPrime)
+	for i := min; i < prime; i++ {
+		assert.False(t, isPrime(i) && (i-1)%hashPrime != 0)
+	}
+}
+
+func Test_GivenOldSize_WhenExpanding_ThenPrimeAtLeastDoubleReturned(t *testing.T) {
+	// Given/When
+	size := expandPrime(17)
+
+	// Then
+	assert.Equal(t, 37, size)
+	assert.True(t, isPrime(size))
+}
+
+func Test_GivenGrowingSet_WhenTableResizes_ThenEverySizeIsPrime(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+	sizes := map[int]bool{len(hs.slots): true}
+
+	// When
+	for i := 1; i <= 5000; i++ {
+		assert.NoError(t, hs.Insert(intKey(i)))
+		sizes[len(hs.slots)] = true
+	}
+
+	// Then
+	assert.Greater(t, len(sizes), 5)
+	for size := range sizes {
+		assert.True(t, isPrime(size), "size %d is not prime", size)
+	}
+	for i := 1; i <= 5000; i++ {
+		assert.True(t, hs.Find(intKey(i)))
+	}
+}
+
+func Test_GivenEveryReachedTableSize_WhenProbingFromHashKey_ThenAllSlotsVisited(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+	var sizes []int
+	sizes = append(sizes, len(hs.slots))
+	for i := 1; i <= 5000; i++ {
+		hs.Insert(intKey(i))
+		if sizes[len(sizes)-1] != len(hs.slots) {
+			sizes = append(sizes, len(hs.slots))
+		}
+	}
+
+	for _, size := range sizes {
+		probe := NewDynamicHashSet[intKey]()
+		probe.slots = make([]Slot[intKey], size)
+
+		for key := intKey(-100); key <= 100; key++ {
+			// When
+			_, seed, step := probe.hashKey(key)
+			visited := make([]bool, size)
+			idx, distinct := seed, 0
+			for i := 0; i < size; i++ {
+				if !visited[idx] {
+					visited[idx] = true
+					distinct++
+				}
+				idx = (idx + step) % size
+			}
+
+			// Then
+			if !assert.Equal(t, size, distinct, "key %d with step %d does not cover table of size %d", key, step, size) {
+				return
+			}
+		}
+	}
+}
+
+func Test_GivenFullTableBeforeResize_WhenInsertingOneMore_ThenInsertSucceeds(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+	for i := 1; i <= hs.loadSize; i++ {
+		hs.Insert(intKey(i))
+	}
+	oldSize := len(hs.slots)
+
+	// When
+	err := hs.Insert(intKey(1000))
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, expandPrime(oldSize), len(hs.slots))
+	assert.True(t, hs.Find(intKey(1000)))
+}
+
+
+// DYNAMIC HASH SET - TOMBSTONE REUSE
+
+type collidingKey int
+
+func (k collidingKey) HashCode() int {
+	return 7
+}
+
+func Test_GivenChainThroughDeletedSlot_WhenTombstoneReused_ThenKeysBehindItStillFound(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[collidingKey]()
+	hs.Insert(1)
+	hs.Insert(2)
+	hs.Insert(3)
+	hs.Delete(1)
+
+	// When
+	err := hs.Insert(4)
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, hs.Find(2))
+	assert.True(t, hs.Find(3))
+	assert.True(t, hs.Find(4))
+	assert.False(t, hs.Find(1))
+	assert.Equal(t, 3, hs.Count())
+}
+
+// DYNAMIC HASH MAP
+
+func Test_GivenEmptyMap_WhenPuttingKey_ThenValueCanBeRead(t *testing.T) {
+	// Given
+	hm := NewDynamicHashMap[intKey, string]()
+
+	// When
+	hm.Put(1, "one")
+
+	// Then
+	value, err := hm.Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "one", value)
+	assert.True(t, hm.IsKey(1))
+	assert.Equal(t, 1, hm.Count())
+}
+
+func Test_GivenMapWithKey_WhenPuttingSameKey_ThenValueOverwrittenAndCountUnchanged(t *testing.T) {
+	// Given
+	hm := NewDynamicHashMap[intKey, string]()
+	hm.Put(1, "one")
+
+	// When
+	hm.Put(1, "uno")
+
+	// Then
+	value, _ := hm.Get(1)
+	assert.Equal(t, "uno", value)
+	assert.Equal(t, 1, hm.Count())
+}
+
+func Test_GivenEmptyMap_WhenGettingMissingKey_ThenErrorAndZeroValueReturned(t *testing.T) {
+	// Given
+	hm := NewDynamicHashMap[intKey, int]()
+
+	// When
+	value, err := hm.Get(5)
+
+	// Then
+	assert.Error(t, err)
+	assert.Equal(t, 0, value)
+	assert.False(t, hm.IsKey(5))
+}
+
+func Test_GivenMapWithKey_WhenDeleting_ThenKeyGoneAndValueZeroed(t *testing.T) {
+	// Given
+	hm := NewDynamicHashMap[intKey, *int]()
+	v := 42
+	hm.Put(1, &v)
+	hm.Put(2, &v)
+
+	// When
+	err := hm.Delete(1)
+
+	// Then
+	assert.NoError(t, err)
+	assert.False(t, hm.IsKey(1))
+	assert.True(t, hm.IsKey(2))
+	assert.Equal(t, 1, hm.Count())
+	for i := range hm.slots {
+		if hm.slots[i].isTombstone() {
+			assert.Nil(t, hm.values[i])
+		}
+	}
+}
+
+func Test_GivenMap_WhenDeletingMissingKey_ThenErrorReturned(t *testing.T) {
+	// Given
+	hm := NewDynamicHashMap[intKey, int]()
+	hm.Put(1, 1)
+
+	// When
+	err := hm.Delete(2)
+
+	// Then
+	assert.Error(t, err)
+	assert.Equal(t, 1, hm.Count())
+}
+
+func Test_GivenMap_WhenPuttingManyKeys_ThenResizesAndKeepsAllPairs(t *testing.T) {
+	// Given
+	hm := NewDynamicHashMap[intKey, int]()
+
+	// When
+	for i := 1; i <= 1000; i++ {
+		hm.Put(intKey(i), i*10)
+	}
+
+	// Then
+	assert.Equal(t, 1000, hm.Count())
+	assert.Greater(t, len(hm.slots), InitialSize)
+	assert.Equal(t, len(hm.slots), len(hm.values))
+	for i := 1; i <= 1000; i++ {
+		value, err := hm.Get(intKey(i))
+		assert.NoError(t, err)
+		assert.Equal(t, i*10, value)
+	}
+}
+
+func Test_GivenCollidingKeys_WhenDeletingAndReinserting_ThenTombstonesReused(t *testing.T) {
+	// Given
+	hm := NewDynamicHashMap[collidingKey, string]()
+	hm.Put(1, "a")
+	hm.Put(2, "b")
+	hm.Put(3, "c")
+	hm.Delete(2)
+
+	// When
+	hm.Put(4, "d")
+
+	// Then
+	assert.Equal(t, 3, hm.Count())
+	for key, expected := range map[collidingKey]string{1: "a", 3: "c", 4: "d"} {
+		value, err := hm.Get(key)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value)
+	}
+	assert.False(t, hm.IsKey(2))
+}
+
+func Test_GivenMap_WhenRangingOverKeysAndValues_ThenAllEntriesYielded(t *testing.T) {
+	// Given
+	hm := NewDynamicHashMap[intKey, string]()
+	hm.Put(1, "a")
+	hm.Put(2, "b")
+	hm.Put(3, "c")
+	hm.Delete(2)
+
+	// When
+	keys := slices.Collect(hm.Keys())
+	values := slices.Collect(hm.Values())
+
+	// Then
+	slices.Sort(keys)
+	slices.Sort(values)
+	assert.Equal(t, []intKey{1, 3}, keys)
+	assert.Equal(t, []string{"a", "c"}, values)
+}
+
+func Test_GivenMapIteration_WhenOverwritingValues_ThenNoPanicAndValuesUpdated(t *testing.T) {
+	// Given
+	hm := NewDynamicHashMap[intKey, int]()
+	hm.Put(1, 1)
+	hm.Put(2, 2)
+
+	// When
+	assert.NotPanics(t, func() {
+		for key, value := range hm.All() {
+			hm.Put(key, value*10)
+		}
+	})
+
+	// Then
+	first, _ := hm.Get(1)
+	second, _ := hm.Get(2)
+	assert.Equal(t, 10, first)
+	assert.Equal(t, 20, second)
+	assert.Equal(t, 2, hm.Count())
+}
+
+func Test_GivenMapIteration_WhenPutting_ThenPanics(t *testing.T) {
+	// Given
+	hm := NewDynamicHashMap[intKey, int]()
+	hm.Put(1, 1)
+	hm.Put(2, 2)
+
+	// When/Then
+	assert.PanicsWithError(t, errModifiedDuringIteration.Error(), func() {
+		for key := range hm.Keys() {
+			hm.Put(key+100, 0)
+		}
+	})
+}
+
+
+// HASHABLE ADAPTERS
+
+func Test_GivenEqualAdapterKeys_WhenHashing_ThenSameHashCode(t *testing.T) {
+	// Given/When/Then
+	assert.Equal(t, StringKey("abc").HashCode(), StringKey("abc").HashCode())
+	assert.Equal(t, IntKey(42).HashCode(), IntKey(42).HashCode())
+	assert.Equal(t, NewBytesKey([]byte{1, 2, 3}).HashCode(), NewBytesKey([]byte{1, 2, 3}).HashCode())
+	assert.Equal(t, ComparableKey[point]{point{1, 2}}.HashCode(), ComparableKey[point]{point{1, 2}}.HashCode())
+}
+
+func Test_GivenDifferentAdapterKeys_WhenHashing_ThenHashCodesDiffer(t *testing.T) {
+	// Given/When/Then
+	assert.NotEqual(t, StringKey("abc").HashCode(), StringKey("abd").HashCode())
+	assert.NotEqual(t, IntKey(1).HashCode(), IntKey(2).HashCode())
+	assert.NotEqual(t, ComparableKey[point]{point{1, 2}}.HashCode(), ComparableKey[point]{point{2, 1}}.HashCode())
+}
+
+func Test_GivenBytesKey_WhenSourceSliceChanges_ThenKeyUnchanged(t *testing.T) {
+	// Given
+	raw := []byte("key")
+	key := NewBytesKey(raw)
+
+	// When
+	raw[0] = 'X'
+
+	// Then
+	assert.Equal(t, []byte("key"), key.Bytes())
+}
+
+func Test_GivenSetOfStringKeys_WhenInsertingAndFinding_ThenWorksWithoutWrapper(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[StringKey]()
+
+	// When
+	for _, word := range []string{"alpha", "beta", "gamma", "delta"} {
+		hs.Insert(StringKey(word))
+	}
+
+	// Then
+	assert.Equal(t, 4, hs.Count())
+	assert.True(t, hs.Find("gamma"))
+	assert.False(t, hs.Find("omega"))
+}
+
+func Test_GivenMapWithComparableStructKeys_WhenPuttingAndGetting_ThenValuesReturned(t *testing.T) {
+	// Given
+	hm := NewDynamicHashMap[ComparableKey[point], string]()
+
+	// When
+	hm.Put(ComparableKey[point]{point{1, 2}}, "a")
+	hm.Put(ComparableKey[point]{point{3, 4}}, "b")
+
+	// Then
+	value, err := hm.Get(ComparableKey[point]{point{3, 4}})
+	assert.NoError(t, err)
+	assert.Equal(t, "b", value)
+	assert.False(t, hm.IsKey(ComparableKey[point]{point{4, 3}}))
+}
+
+func Test_GivenHasherFunction_WhenCreatingSetWithFunc_ThenPlainTypesUsableAsKeys(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSetFunc(func(p point) int { return p.x*31 + p.y })
+
+	// When
+	for i := 1; i <= 100; i++ {
+		hs.Insert(point{i, -i})
+	}
+
+	// Then
+	assert.Equal(t, 100, hs.Count())
+	assert.True(t, hs.Find(point{50, -50}))
+	assert.False(t, hs.Find(point{50, 50}))
+}
+
+func Test_GivenHasherFunction_WhenCreatingMapWithFunc_ThenStringKeysUsable(t *testing.T) {
+	// Given
+	hm := NewDynamicHashMapFunc[string, int](func(s string) int { return len(s) })
+
+	// When
+	hm.Put("a", 1)
+	hm.Put("b", 2)
+	hm.Put("cc", 3)
+
+	// Then
+	for key, expected := range map[string]int{"a": 1, "b": 2, "cc": 3} {
+		value, err := hm.Get(key)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value)
+	}
+}
+
+type point struct {
+	x, y int
+}
+
+
+// DELETE
+
+func Test_GivenHashTableWithValue_WhenDeleting_ThenReturnsItsIndexAndFindFails(t *testing.T) {
+	// Given
+	ht := Init(17, 3)
+	idx := ht.Put("test")
+
+	// When
+	deleted := ht.Delete("test")
+
+	// Then
+	assert.Equal(t, idx, deleted)
+	assert.Equal(t, -1, ht.Find("test"))
+	assert.Equal(t, 0, ht.Count())
+}
+
+func Test_GivenHashTable_WhenDeletingMissingValue_ThenReturnsMinusOne(t *testing.T) {
+	// Given
+	ht := Init(17, 3)
+	ht.Put("test")
+
+	// When
+	deleted := ht.Delete("other")
+
+	// Then
+	assert.Equal(t, -1, deleted)
+	assert.Equal(t, 1, ht.Count())
+}
+
+func Test_GivenCollisionChain_WhenDeletingFirstValue_ThenValuesBehindStillFound(t *testing.T) {
+	// Given - "a", "f" and "k" share the same start slot in a table of 5
+	ht := Init(5, 1)
+	ht.Put("a")
+	ht.Put("f")
+	idxK := ht.Put("k")
+
+	// When
+	ht.Delete("a")
+
+	// Then
+	assert.Equal(t, -1, ht.Find("a"))
+	assert.NotEqual(t, -1, ht.Find("f"))
+	assert.Equal(t, idxK, ht.Find("k"))
+}
+
+func Test_GivenTombstoneInChain_WhenPuttingNewValue_ThenTombstoneReused(t *testing.T) {
+	// Given
+	ht := Init(5, 1)
+	idxA := ht.Put("a")
+	ht.Put("f")
+	ht.Delete("a")
+
+	// When
+	idx := ht.Put("k")
+
+	// Then
+	assert.Equal(t, idxA, idx)
+	assert.Equal(t, 2, ht.Count())
+}
+
+func Test_GivenTombstoneBeforeExistingValue_WhenPuttingSameValue_ThenDuplicateRejected(t *testing.T) {
+	// Given
+	ht := Init(5, 1)
+	ht.Put("a")
+	ht.Put("f")
+	ht.Delete("a")
+
+	// When
+	idx := ht.Put("f")
+
+	// Then
+	assert.Equal(t, -1, idx)
+	assert.Equal(t, 1, ht.Count())
+}
+
+func Test_GivenFullTableWithTombstone_WhenPuttingValue_ThenTombstoneSlotUsed(t *testing.T) {
+	// Given
+	ht := Init(3, 1)
+	ht.Put("a")
+	idxB := ht.Put("b")
+	ht.Put("c")
+	ht.Delete("b")
+
+	// When
+	idx := ht.Put("d")
+
+	// Then
+	assert.Equal(t, idxB, idx)
+	assert.Equal(t, idxB, ht.Find("d"))
+}
+
+// COUNT
+
+func Test_GivenPutsAndDeletes_WhenCounting_ThenOnlyLiveValuesCounted(t *testing.T) {
+	// Given
+	ht := Init(17, 3)
+	ht.Put("a")
+	ht.Put("b")
+	ht.Put("b")
+	ht.Put("c")
+	ht.Delete("a")
+	ht.Delete("z")
+
+	// When/Then
+	assert.Equal(t, 2, ht.Count())
+}
+
+// GROWTH
+
+func Test_GivenGrowingTable_WhenLoadThresholdCrossed_ThenTableGrowsAndKeepsValues(t *testing.T) {
+	// Given
+	ht := InitGrowing(3, 1, 0.75)
+
+	// When
+	for _, v := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		assert.NotEqual(t, -1, ht.Put(v))
+	}
+
+	// Then
+	assert.Greater(t, len(ht.slots), 3)
+	assert.Equal(t, len(ht.slots), ht.size)
+	assert.Equal(t, 7, ht.Count())
+	for _, v := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		idx := ht.Find(v)
+		assert.NotEqual(t, -1, idx)
+		assert.Equal(t, v, ht.slots[idx])
+	}
+}
+
+func Test_GivenGrowingTable_WhenPutting_ThenReturnedIndexIsCurrentSlot(t *testing.T) {
+	// Given
+	ht := InitGrowing(3, 1, 0.5)
+	ht.Put("a")
+
+	// When
+	idx := ht.Put("b")
+
+	// Then
+	assert.Equal(t, idx, ht.Find("b"))
+	assert.Equal(t, "b", ht.slots[idx])
+}
+
+func Test_GivenGrowingTableWithStepDividingPrime_WhenGrowing_ThenNewSizeCoprimeWithStep(t *testing.T) {
+	// Given
+	ht := InitGrowing(3, 7, 0.7)
+
+	// When
+	for i := 0; i < 20; i++ {
+		ht.Put(string(rune('a' + i)))
+	}
+
+	// Then
+	assertProbesEverySlot(t, ht)
+	assert.Equal(t, 20, ht.Count())
+	for i := 0; i < 20; i++ {
+		assert.NotEqual(t, -1, ht.Find(string(rune('a'+i))))
+	}
+}
+
+func Test_GivenSizeNotCoprimeWithStep_WhenPuttingUpToLoadThreshold_ThenEveryPutSucceeds(t *testing.T) {
+	for _, tt := range []struct {
+		size    int
+		step    int
+		maxLoad float64
+	}{
+		{15, 5, 0.69},
+		{10, 2, 0.7},
+		{9, 3, 0.9},
+	} {
+		// Given
+		ht := InitGrowing(tt.size, tt.step, tt.maxLoad)
+
+		// When
+		for i := 0; i < 40; i++ {
+			assert.NotEqual(t, -1, ht.Put(fmt.Sprintf("value-%d", i)))
+		}
+
+		// Then
+		assertProbesEverySlot(t, ht)
+		assert.Equal(t, 40, ht.Count())
+		for i := 0; i < 40; i++ {
+			assert.NotEqual(t, -1, ht.Find(fmt.Sprintf("value-%d", i)))
+		}
+	}
+}
+
+func Test_GivenGrowingTableFullOfTombstones_WhenPutting_ThenRehashedWithoutGrowing(t *testing.T) {
+	// Given
+	ht := InitGrowing(17, 3, 0.5)
+	for i := 0; i < 8; i++ {
+		ht.Put(string(rune('a' + i)))
+	}
+	for i := 0; i < 7; i++ {
+		ht.Delete(string(rune('a' + i)))
+	}
+
+	// When
+	idx := ht.Put("z")
+
+	// Then
+	assert.NotEqual(t, -1, idx)
+	assert.Equal(t, 17, len(ht.slots))
+	assert.Equal(t, 0, ht.removed)
+	assert.Equal(t, 2, ht.Count())
+	assert.NotEqual(t, -1, ht.Find("h"))
+}
+
+func assertProbesEverySlot(t *testing.T, ht HashTable) {
+	size := int64(len(ht.slots))
+	gcd := new(big.Int).GCD(nil, nil, big.NewInt(int64(ht.step)), big.NewInt(size))
+	assert.Equal(t, int64(1), gcd.Int64())
+
+	visited := make([]bool, len(ht.slots))
+	for idx, i := 0, 0; i < len(ht.slots); idx, i = (idx+ht.step)%len(ht.slots), i+1 {
+		visited[idx] = true
+	}
+	assert.NotContains(t, visited, false)
+}
+
+// POLYNOMIAL HASH
+
+func bucketCounts(keys []string, size int, hash func(string) int) []int {
+	counts := make([]int, size)
+	for _, key := range keys {
+		counts[hash(key)]++
+	}
+	return counts
+}
+
+func longKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("%032d-customer-session-identifier", i)
+	}
+	return keys
+}
+
+func Test_GivenShortKeys_WhenHashing_ThenSameSlotsAsPowerSum(t *testing.T) {
+	// Given
+	ht := Init(17, 3)
+
+	for _, key := range []string{"", "a", "test", "hello", "abcdefghij"} {
+		// When
+		expected := 0
+		for i := 0; i < len(key); i++ {
+			pow := 1
+			for j := 0; j < len(key)-i; j++ {
+				pow *= 26
+			}
+			expected += int(key[i]) * pow
+		}
+
+		// Then
+		assert.Equal(t, expected%17, ht.HashFun(key), key)
+	}
+}
+
+func Test_GivenLongKeysWithCommonPrefix_WhenHashing_ThenSlotsStayBalanced(t *testing.T) {
+	// Given
+	ht := Init(17, 3)
+	keys := longKeys(17 * 200)
+
+	// When
+	counts := bucketCounts(keys, 17, ht.HashFun)
+
+	// Then
+	for slot, count := range counts {
+		assert.Greater(t, count, 100, "slot %d", slot)
+		assert.Less(t, count, 300, "slot %d", slot)
+	}
+}
+
+func Test_GivenLongKeys_WhenHashingWithSeed_ThenSlotsStayBalanced(t *testing.T) {
+	// Given
+	ht := InitSeeded(17, 3, 0x9E3779B97F4A7C15)
+	keys := longKeys(17 * 200)
+
+	// When
+	counts := bucketCounts(keys, 17, ht.HashFun)
+
+	// Then
+	for slot, count := range counts {
+		assert.Greater(t, count, 100, "slot %d", slot)
+		assert.Less(t, count, 300, "slot %d", slot)
+	}
+}
+
+func Test_GivenSameSeed_WhenHashing_ThenDeterministic(t *testing.T) {
+	// Given
+	first := InitSeeded(101, 3, 42)
+	second := InitSeeded(101, 3, 42)
+
+	// When/Then
+	for _, key := range longKeys(50) {
+		idx := first.HashFun(key)
+		assert.Equal(t, idx, second.HashFun(key))
+		assert.GreaterOrEqual(t, idx, 0)
+		assert.Less(t, idx, 101)
+	}
+}
+
+func Test_GivenDifferentSeeds_WhenHashingSameKeys_ThenSlotsDiffer(t *testing.T) {
+	// Given
+	first := InitSeeded(101, 3, 1)
+	second := InitSeeded(101, 3, 2)
+	differ := 0
+
+	// When
+	for _, key := range longKeys(100) {
+		if first.HashFun(key) != second.HashFun(key) {
+			differ++
+		}
+	}
+
+	// Then
+	assert.Greater(t, differ, 80)
+}
+
+func Test_GivenSeededTable_WhenPuttingFindingAndGrowing_ThenValuesFound(t *testing.T) {
+	// Given
+	ht := InitSeeded(17, 3, 7)
+	ht.maxLoad = 0.7
+	keys := longKeys(100)
+
+	// When
+	for _, key := range keys {
+		assert.NotEqual(t, -1, ht.Put(key))
+	}
+
+	// Then
+	assert.Equal(t, uint64(7), ht.seed)
+	for _, key := range keys {
+		assert.NotEqual(t, -1, ht.Find(key))
+	}
+}
+
+func Test_GivenSeedZero_WhenHashingAndGrowing_ThenSeededHashUsed(t *testing.T) {
+	// Given
+	seeded := InitSeeded(17, 3, 0)
+	seeded.maxLoad = 0.7
+	plain := Init(17, 3)
+	keys := longKeys(100)
+
+	// When
+	differ := 0
+	for _, key := range keys[:50] {
+		if seeded.HashFun(key) != plain.HashFun(key) {
+			differ++
+		}
+	}
+	for _, key := range keys {
+		seeded.Put(key)
+	}
+
+	// Then
+	assert.Greater(t, differ, 25)
+	assert.True(t, seeded.seeded)
+	for _, key := range keys {
+		assert.Equal(t, SeededPolyHash(key, 0, len(seeded.slots)), seeded.HashFun(key))
+		assert.NotEqual(t, -1, seeded.Find(key))
+	}
+}
+
+func Test_GivenLargeFactors_WhenMultiplyingModMersenne_ThenMatchesBigInt(t *testing.T) {
+	// Given
+	mod := new(big.Int).SetUint64(mersenne61)
+	values := []uint64{0, 1, 2, 26, mersenne61 - 1, mersenne61 - 2, 1 << 60, 0x1234567890ABCDE, 0x1FFFFFFF00000001}
+
+	for _, a := range values {
+		for _, b := range values {
+			// When
+			result := mulMod61(a%mersenne61, b%mersenne61)
+
+			// Then
+			expected := new(big.Int).Mul(new(big.Int).SetUint64(a%mersenne61), new(big.Int).SetUint64(b%mersenne61))
+			expected.Mod(expected, mod)
+			assert.Equal(t, expected.Uint64(), result, "%d * %d", a, b)
+		}
+	}
+}
+
+// ZERO VALUED KEYS
+
+func Test_GivenZeroKey_WhenInsertingAndFinding_ThenFound(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+
+	// When
+	err := hs.Insert(intKey(0))
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, hs.Find(intKey(0)))
+	assert.Equal(t, 1, hs.Count())
+	assert.Equal(t, []intKey{0}, slices.Collect(hs.All()))
+}
+
+func Test_GivenZeroKeyInserted_WhenInsertingAgain_ThenDuplicateError(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+	hs.Insert(intKey(0))
+
+	// When
+	err := hs.Insert(intKey(0))
+
+	// Then
+	assert.Error(t, err)
+	assert.Equal(t, 1, hs.Count())
+}
+
+func Test_GivenZeroKeyInserted_WhenDeleting_ThenNotFoundAndReinsertable(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+	hs.Insert(intKey(0))
+
+	// When
+	err := hs.Delete(intKey(0))
+
+	// Then
+	assert.NoError(t, err)
+	assert.False(t, hs.Find(intKey(0)))
+	assert.Error(t, hs.Delete(intKey(0)))
+	assert.Equal(t, 0, hs.Count())
+	assert.NoError(t, hs.Insert(intKey(0)))
+	assert.True(t, hs.Find(intKey(0)))
+}
+
+func Test_GivenZeroKeyAmongOthers_WhenSetResizes_ThenZeroKeyKept(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+	hs.Insert(intKey(0))
+	initialSize := len(hs.slots)
+
+	// When
+	for i := 1; i <= 100; i++ {
+		assert.NoError(t, hs.Insert(intKey(i)))
+	}
+
+	// Then
+	assert.Greater(t, len(hs.slots), initialSize)
+	assert.Equal(t, 101, hs.Count())
+	for i := 0; i <= 100; i++ {
+		assert.True(t, hs.Find(intKey(i)))
+	}
+}
+
+func Test_GivenEmptyStringKey_WhenInsertingFindingAndDeleting_ThenWorks(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[StringKey]()
+	hs.Insert(StringKey("a"))
+
+	// When
+	err := hs.Insert(StringKey(""))
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, hs.Find(StringKey("")))
+	assert.NoError(t, hs.Delete(StringKey("")))
+	assert.False(t, hs.Find(StringKey("")))
+	assert.True(t, hs.Find(StringKey("a")))
+}
+
+func Test_GivenZeroStructKey_WhenInsertingWithFunc_ThenFound(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSetFunc(func(p point) int { return p.x*31 + p.y })
+
+	// When
+	err := hs.Insert(point{})
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, hs.Find(point{}))
+	assert.False(t, hs.Find(point{x: 1}))
+}
+
+func Test_GivenSaltedSet_WhenUsingZeroKeyThroughResize_ThenWorks(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSetSalt[intKey]()
+	assert.NoError(t, hs.Insert(intKey(0)))
+
+	// When
+	for i := 1; i <= 100; i++ {
+		assert.NoError(t, hs.Insert(intKey(i)))
+	}
+	err := hs.Delete(intKey(0))
+
+	// Then
+	assert.NoError(t, err)
+	assert.False(t, hs.Find(intKey(0)))
+	assert.Equal(t, 100, hs.Count())
+	for i := 1; i <= 100; i++ {
+		assert.True(t, hs.Find(intKey(i)))
+	}
+	assert.NoError(t, hs.Insert(intKey(0)))
+	assert.True(t, hs.Find(intKey(0)))
+}
+
+func Test_GivenMapWithZeroKey_WhenPuttingAndGetting_ThenValueReturned(t *testing.T) {
+	// Given
+	hm := NewDynamicHashMap[intKey, string]()
+
+	// When
+	hm.Put(intKey(0), "zero")
+
+	// Then
+	value, err := hm.Get(intKey(0))
+	assert.NoError(t, err)
+	assert.Equal(t, "zero", value)
+	assert.Equal(t, 1, hm.Count())
+}
+
+// SET OPERATIONS
+
+func makeIntKeySet(keys ...int) *DynamicHashSet[intKey] {
+	hs := NewDynamicHashSet[intKey]()
+	for _, key := range keys {
+		hs.Insert(intKey(key))
+	}
+	return hs
+}
+
+func sortedKeys(hs *DynamicHashSet[intKey]) []intKey {
+	keys := slices.Collect(hs.All())
+	slices.Sort(keys)
+	return keys
+}
+
+func Test_GivenTwoSets_WhenTakingUnion_ThenKeysOfBoth(t *testing.T) {
+	// Given
+	first := makeIntKeySet(1, 2, 3)
+	second := makeIntKeySet(3, 4)
+
+	// When
+	union := first.Union(second)
+
+	// Then
+	assert.Equal(t, []intKey{1, 2, 3, 4}, sortedKeys(union))
+	assert.Equal(t, 4, union.Count())
+	assert.Equal(t, []intKey{1, 2, 3}, sortedKeys(first))
+	assert.Equal(t, []intKey{3, 4}, sortedKeys(second))
+}
+
+func Test_GivenTwoSets_WhenIntersecting_ThenOnlyCommonKeysEitherWay(t *testing.T) {
+	// Given
+	small := makeIntKeySet(2, 4, 6)
+	large := makeIntKeySet(1, 2, 3, 4, 5, 0)
+
+	// When
+	fromSmall := small.Intersect(large)
+	fromLarge := large.Intersect(small)
+
+	// Then
+	assert.Equal(t, []intKey{2, 4}, sortedKeys(fromSmall))
+	assert.Equal(t, []intKey{2, 4}, sortedKeys(fromLarge))
+	assert.Equal(t, 2, fromLarge.Count())
+}
+
+func Test_GivenTwoSets_WhenTakingDifference_ThenKeysOnlyInFirstEitherWay(t *testing.T) {
+	// Given
+	small := makeIntKeySet(2, 4, 6)
+	large := makeIntKeySet(0, 1, 2, 3, 4, 5)
+
+	// When
+	smallMinusLarge := small.Difference(large)
+	largeMinusSmall := large.Difference(small)
+
+	// Then
+	assert.Equal(t, []intKey{6}, sortedKeys(smallMinusLarge))
+	assert.Equal(t, []intKey{0, 1, 3, 5}, sortedKeys(largeMinusSmall))
+	assert.Equal(t, 4, largeMinusSmall.Count())
+}
+
+func Test_GivenTwoSets_WhenTakingSymmetricDifference_ThenKeysInExactlyOne(t *testing.T) {
+	// Given
+	first := makeIntKeySet(1, 2, 3, 0)
+	second := makeIntKeySet(3, 4)
+
+	// When
+	result := first.SymmetricDifference(second)
+
+	// Then
+	assert.Equal(t, []intKey{0, 1, 2, 4}, sortedKeys(result))
+	assert.Equal(t, sortedKeys(result), sortedKeys(second.SymmetricDifference(first)))
+	assert.Equal(t, 4, result.Count())
+}
+
+func Test_GivenSets_WhenCheckingSubsetAndEquality_ThenContentDecides(t *testing.T) {
+	// Given
+	small := makeIntKeySet(1, 2)
+	large := makeIntKeySet(1, 2, 3)
+	sameAsLarge := makeIntKeySet(3, 2, 1)
+	empty := makeIntKeySet()
+
+	// When/Then
+	assert.True(t, small.IsSubset(large))
+	assert.False(t, large.IsSubset(small))
+	assert.True(t, empty.IsSubset(small))
+	assert.False(t, makeIntKeySet(1, 9).IsSubset(large))
+	assert.True(t, large.Equal(sameAsLarge))
+	assert.False(t, large.Equal(small))
+	assert.False(t, makeIntKeySet(1, 2, 4).Equal(large))
+}
+
+func Test_GivenLargeSets_WhenTakingUnion_ThenResultPresizedAndNeverResized(t *testing.T) {
+	// Given
+	first, second := NewDynamicHashSet[intKey](), NewDynamicHashSet[intKey]()
+	for i := 0; i < 500; i++ {
+		first.Insert(intKey(i))
+		second.Insert(intKey(i + 1000))
+	}
+
+	// When
+	union := first.Union(second)
+
+	// Then
+	assert.Equal(t, 1000, union.Count())
+	assert.Equal(t, sizeForCapacity(1000), len(union.slots))
+	assert.GreaterOrEqual(t, union.loadSize, 1000)
+}
+
+func Test_GivenSetsWhichMostlyCancelOut_WhenTakingDifferences_ThenResultsKeepPresizedTable(t *testing.T) {
+	// Given
+	first, second := NewDynamicHashSet[intKey](), NewDynamicHashSet[intKey]()
+	for i := 0; i < 100; i++ {
+		first.Insert(intKey(i))
+	}
+	for i := 0; i < 90; i++ {
+		second.Insert(intKey(i))
+	}
+
+	// When
+	difference := first.Difference(second)
+	symmetric := first.SymmetricDifference(second)
+
+	// Then
+	assert.Equal(t, 10, difference.Count())
+	assert.Equal(t, sizeForCapacity(100), len(difference.slots))
+	assert.Equal(t, 0, difference.tombstones)
+	assert.Equal(t, 10, symmetric.Count())
+	assert.Equal(t, sizeForCapacity(190), len(symmetric.slots))
+	assert.Equal(t, 0, symmetric.tombstones)
+	assert.Equal(t, sortedKeys(difference), sortedKeys(symmetric))
+}
+
+func Test_GivenCapacities_WhenComputingSize_ThenLoadSizeFitsCapacity(t *testing.T) {
+	for _, capacity := range []int{0, 1, 12, 13, 100, 1000, 12345} {
+		// When
+		size := sizeForCapacity(capacity)
+
+		// Then
+		assert.True(t, isPrime(size))
+		assert.GreaterOrEqual(t, loadSizeFor(size), capacity)
+	}
+}
+
+func Test_GivenSet_WhenUnionWithOther_ThenKeysAddedInPlace(t *testing.T) {
+	// Given
+	hs := makeIntKeySet(1, 2)
+	other := NewDynamicHashSet[intKey]()
+	for i := 0; i < 100; i++ {
+		other.Insert(intKey(i))
+	}
+
+	// When
+	hs.UnionWith(other)
+
+	// Then
+	assert.Equal(t, 100, hs.Count())
+	assert.True(t, hs.Equal(other))
+	assert.Equal(t, sizeForCapacity(102), len(hs.slots))
+}
+
+func Test_GivenSet_WhenIntersectWithSmallerOrLarger_ThenOnlyCommonKeysKept(t *testing.T) {
+	// Given
+	large := makeIntKeySet(0, 1, 2, 3, 4, 5)
+	small := makeIntKeySet(1, 4, 9)
+
+	// When
+	large.IntersectWith(small)
+	small.IntersectWith(makeIntKeySet(0, 1, 2, 3, 4, 5))
+
+	// Then
+	assert.Equal(t, []intKey{1, 4}, sortedKeys(large))
+	assert.Equal(t, 2, large.Count())
+	assert.Equal(t, []intKey{1, 4}, sortedKeys(small))
+	assert.Equal(t, 2, small.Count())
+	assert.False(t, small.Find(9))
+	assert.NoError(t, small.Insert(9))
+}
+
+func Test_GivenSetBeingIterated_WhenIntersectingInPlace_ThenIterationPanics(t *testing.T) {
+	// Given
+	hs := makeIntKeySet(1, 2, 3, 4)
+
+	// When/Then
+	assert.PanicsWithError(t, errModifiedDuringIteration.Error(), func() {
+		for range hs.All() {
+			hs.IntersectWith(makeIntKeySet(1))
+		}
+	})
+}
+
+func Test_GivenSaltedAndPlainSets_WhenCombining_ThenHashFunctionsDoNotMatter(t *testing.T) {
+	// Given
+	salted := NewDynamicHashSetSalt[intKey]()
+	for i := 0; i < 10; i++ {
+		salted.Insert(intKey(i))
+	}
+	plain := makeIntKeySet(5, 6, 7, 8, 9, 10, 11)
+
+	// When
+	intersection := plain.Intersect(salted.DynamicHashSet)
+	union := salted.Union(plain)
+
+	// Then
+	assert.Equal(t, []intKey{5, 6, 7, 8, 9}, sortedKeys(intersection))
+	assert.Equal(t, 12, union.Count())
+	for i := 0; i <= 11; i++ {
+		assert.True(t, union.Find(intKey(i)))
+	}
+}
+
+// TOMBSTONES AND SHRINKING
+
+func Test_GivenSet_WhenDeletingAndReusingSlots_ThenTombstonesCounted(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[collidingKey]()
+	hs.Insert(1)
+	hs.Insert(2)
+	hs.Insert(3)
+
+	// When
+	hs.Delete(1)
+	hs.Delete(2)
+	tombstonesAfterDelete := hs.tombstones
+	hs.Insert(4)
+
+	// Then
+	assert.Equal(t, 2, tombstonesAfterDelete)
+	assert.Equal(t, 1, hs.tombstones)
+	assert.Equal(t, 2, hs.Count())
+}
+
+func Test_GivenDeleteHeavyWorkload_WhenChurningKeys_ThenTombstonesStayBounded(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+	for i := 0; i < 500; i++ {
+		hs.Insert(intKey(i))
+	}
+
+	// When
+	for i := 0; i < 5000; i++ {
+		assert.NoError(t, hs.Delete(intKey(i)))
+		assert.NoError(t, hs.Insert(intKey(i+500)))
+		assert.LessOrEqual(t, float64(hs.tombstones), TombstoneFactor*float64(len(hs.slots)))
+	}
+
+	// Then
+	assert.Equal(t, 500, hs.Count())
+	for i := 5000; i < 5500; i++ {
+		assert.True(t, hs.Find(intKey(i)))
+	}
+	assert.False(t, hs.Find(intKey(4999)))
+}
+
+func Test_GivenManyTombstones_WhenDeletingPastThreshold_ThenRehashedInSameSlots(t *testing.T) {
+	// Given
+	hs := makeIntKeySet(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	slots := hs.slots
+
+	// When
+	for i := 0; i < 5; i++ {
+		hs.Delete(intKey(i))
+	}
+
+	// Then
+	assert.Equal(t, 0, hs.tombstones)
+	assert.Same(t, &slots[0], &hs.slots[0])
+	assert.Equal(t, 6, hs.Count())
+	for i := 5; i <= 10; i++ {
+		assert.True(t, hs.Find(intKey(i)))
+	}
+}
+
+func Test_GivenSetMostlyLive_WhenMakingRoom_ThenGrows(t *testing.T) {
+	// Given
+	hs := makeIntKeySet(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	hs.Delete(10)
+	size := len(hs.slots)
+
+	// When
+	hs.makeRoom()
+
+	// Then
+	assert.Greater(t, len(hs.slots), size)
+	assert.Equal(t, 0, hs.tombstones)
+	assert.Equal(t, 10, hs.Count())
+}
+
+func Test_GivenSetWithTombstonesAndFewLiveKeys_WhenMakingRoom_ThenRehashedWithoutGrowing(t *testing.T) {
+	// Given
+	hs := makeIntKeySet(0, 1, 2, 3, 4, 5, 6, 7)
+	for i := 0; i < 4; i++ {
+		hs.Delete(intKey(i))
+	}
+	size := len(hs.slots)
+
+	// When
+	hs.makeRoom()
+
+	// Then
+	assert.Equal(t, size, len(hs.slots))
+	assert.Equal(t, 0, hs.tombstones)
+	for i := 4; i < 8; i++ {
+		assert.True(t, hs.Find(intKey(i)))
+	}
+}
+
+func Test_GivenLargeSet_WhenDeletingMostKeys_ThenTableShrinks(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+	for i := 0; i < 2000; i++ {
+		hs.Insert(intKey(i))
+	}
+	grownSize := len(hs.slots)
+
+	// When
+	for i := 0; i < 1990; i++ {
+		hs.Delete(intKey(i))
+	}
+
+	// Then
+	assert.Less(t, len(hs.slots), grownSize/10)
+	assert.True(t, isPrime(len(hs.slots)))
+	assert.Equal(t, 10, hs.Count())
+	for i := 1990; i < 2000; i++ {
+		assert.True(t, hs.Find(intKey(i)))
+	}
+}
+
+func Test_GivenEmptiedSet_WhenShrinking_ThenNeverBelowInitialSize(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+	for i := 0; i < 100; i++ {
+		hs.Insert(intKey(i))
+	}
+
+	// When
+	for i := 0; i < 100; i++ {
+		hs.Delete(intKey(i))
+	}
+
+	// Then
+	assert.Equal(t, getPrime(InitialSize), len(hs.slots))
+	assert.Equal(t, 0, hs.Count())
+}
+
+func Test_GivenOversizedSet_WhenTrimmingExcess_ThenSmallestFittingSize(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+	hs.EnsureCapacity(10000)
+	for i := 0; i < 50; i++ {
+		hs.Insert(intKey(i))
+	}
+
+	// When
+	hs.TrimExcess()
+
+	// Then
+	assert.Equal(t, sizeForCapacity(50), len(hs.slots))
+	assert.Equal(t, 50, hs.Count())
+	for i := 0; i < 50; i++ {
+		assert.True(t, hs.Find(intKey(i)))
+	}
+}
+
+func Test_GivenSet_WhenEnsuringCapacity_ThenNoResizeUntilReached(t *testing.T) {
+	// Given
+	hs := NewDynamicHashSet[intKey]()
+
+	// When
+	capacity := hs.EnsureCapacity(1000)
+	size := len(hs.slots)
+	for i := 0; i < 1000; i++ {
+		hs.Insert(intKey(i))
+	}
+
+	// Then
+	assert.GreaterOrEqual(t, capacity, 1000)
+	assert.Equal(t, size, len(hs.slots))
+	assert.Equal(t, capacity, hs.EnsureCapacity(10))
+}
+
+// INCREMENTAL REHASHING
+
+func Test_GivenIncrementalSet_WhenGrowing_ThenKeysMovedInSmallSteps(t *testing.T) {
+	// Given
+	hs := NewIncrementalDynamicHashSet[intKey]()
+	maxMoved := 0
+
+	// When
+	for i := 0; i < 2000; i++ {
+		oldCount := hs.oldCount
+		startedMigration := hs.count-hs.oldCount+hs.tombstones >= hs.loadSize
+		assert.NoError(t, hs.Insert(intKey(i)))
+		if !startedMigration && hs.oldSlots != nil {
+			maxMoved = max(maxMoved, oldCount-hs.oldCount)
+		}
+	}
+
+	// Then
+	assert.LessOrEqual(t, maxMoved, MigrationStep)
+	assert.Equal(t, 2000, hs.Count())
+	for i := 0; i < 2000; i++ {
+		assert.True(t, hs.Find(intKey(i)))
+	}
+}
+
+func Test_GivenIncrementalSetMigrating_WhenFindingAndDeleting_ThenBothTablesUsed(t *testing.T) {
+	// Given
+	hs := NewIncrementalDynamicHashSet[intKey]()
+	i := 0
+	for hs.oldSlots == nil {
+		hs.Insert(intKey(i))
+		i++
+	}
+	inserted := i
+
+	// When
+	foundAll := true
+	for key := 0; key < inserted; key++ {
+		foundAll = foundAll && hs.Find(intKey(key))
+	}
+	stillMigrating := hs.oldSlots != nil && hs.oldCount > 0
+	errDelete := hs.Delete(intKey(0))
+	errDuplicate := hs.Insert(intKey(1))
+
+	// Then
+	assert.True(t, stillMigrating)
+	assert.True(t, foundAll)
+	assert.NoError(t, errDelete)
+	assert.EqualError(t, errDuplicate, "duplicate key")
+	assert.False(t, hs.Find(intKey(0)))
+	assert.Equal(t, inserted-1, hs.Count())
+}
+
+func Test_GivenIncrementalSetMigrating_WhenOperationsContinue_ThenMigrationCompletes(t *testing.T) {
+	// Given
+	hs := NewIncrementalDynamicHashSet[intKey]()
+	i := 0
+	for hs.oldSlots == nil {
+		hs.Insert(intKey(i))
+		i++
+	}
+	oldSize := len(hs.oldSlots)
+
+	// When
+	operations := 0
+	for hs.oldSlots != nil {
+		assert.NoError(t, hs.Delete(intKey(operations)))
+		operations++
+	}
+
+	// Then
+	assert.LessOrEqual(t, operations, oldSize/MigrationStep+1)
+	assert.Equal(t, 0, hs.oldCount)
+	assert.Equal(t, i-operations, hs.Count())
+	for key := operations; key < i; key++ {
+		assert.True(t, hs.Find(intKey(key)))
+	}
+}
+
+func Test_GivenIncrementalSetMigrating_WhenIterating_ThenEveryKeyYieldedOnce(t *testing.T) {
+	// Given
+	hs := NewIncrementalDynamicHashSet[intKey]()
+	i := 0
+	for hs.oldSlots == nil || hs.oldCount == hs.count {
+		hs.Insert(intKey(i))
+		i++
+	}
+
+	// When
+	keys := sortedKeys(hs)
+
+	// Then
+	assert.Len(t, keys, i)
+	for key := 0; key < i; key++ {
+		assert.Equal(t, intKey(key), keys[key])
+	}
+}
+
+func Test_GivenIncrementalSet_WhenChurningKeys_ThenSameContentAsMap(t *testing.T) {
+	// Given
+	hs := NewIncrementalDynamicHashSet[intKey]()
+	expected := make(map[intKey]bool)
+	random := rand.New(rand.NewSource(42))
+
+	// When
+	for i := 0; i < 20000; i++ {
+		key := intKey(random.Intn(3000))
+		if random.Intn(3) == 0 {
+			assert.Equal(t, expected[key], hs.Delete(key) == nil)
+			delete(expected, key)
+		} else {
+			assert.Equal(t, !expected[key], hs.Insert(key) == nil)
+			expected[key] = true
+		}
+	}
+
+	// Then
+	assert.Equal(t, len(expected), hs.Count())
+	for key := intKey(0); key < 3000; key++ {
+		assert.Equal(t, expected[key], hs.Find(key))
+	}
+	assert.Len(t, sortedKeys(hs), len(expected))
+}
+
+func Test_GivenIncrementalSetMigrating_WhenEnsuringCapacity_ThenMigrationFinishedFirst(t *testing.T) {
+	// Given
+	hs := NewIncrementalDynamicHashSet[intKey]()
+	i := 0
+	for hs.oldSlots == nil {
+		hs.Insert(intKey(i))
+		i++
+	}
+
+	// When
+	hs.EnsureCapacity(10000)
+
+	// Then
+	assert.Nil(t, hs.oldSlots)
+	assert.Equal(t, i, hs.Count())
+	for key := 0; key < i; key++ {
+		assert.True(t, hs.Find(intKey(key)))
+	}
+}
+
+// SWISS TABLE
+
+func Test_GivenGroup_WhenMatchingBytes_ThenMaskHasHighBitOfMatchingSlots(t *testing.T) {
+	// Given
+	group := uint64(lowBits * ctrlEmpty)
+	group = group&^(0xFF<<8) | 0x2A<<8
+	group = group&^(0xFF<<40) | 0x2A<<40
+	group = group&^(0xFF<<16) | ctrlDeleted<<16
+
+	// When
+	matches := matchByte(group, 0x2A)
+	empty := matchEmpty(group)
+	free := matchEmptyOrDeleted(group)
+	full := matchFull(group)
+
+	// Then
+	assert.Equal(t, uint64(0x80<<8|0x80<<40), matches)
+	assert.Equal(t, 1, firstMatch(matches))
+	assert.Equal(t, uint64(highBits&^(0x80<<8|0x80<<40|0x80<<16)), empty)
+	assert.Equal(t, uint64(highBits&^(0x80<<8|0x80<<40)), free)
+	assert.Equal(t, matches, full)
+}
+
+func Test_GivenSwissSet_WhenInsertingFindingAndDeleting_ThenWorks(t *testing.T) {
+	// Given
+	hs := NewSwissHashSet[intKey]()
+
+	// When
+	for i := 0; i < 1000; i++ {
+		assert.NoError(t, hs.Insert(intKey(i)))
+	}
+	errDuplicate := hs.Insert(intKey(500))
+	for i := 0; i < 1000; i += 2 {
+		assert.NoError(t, hs.Delete(intKey(i)))
+	}
+	errMissing := hs.Delete(intKey(0))
+
+	// Then
+	assert.EqualError(t, errDuplicate, "duplicate key")
+	assert.EqualError(t, errMissing, "no element found")
+	assert.Equal(t, 500, hs.Count())
+	for i := 0; i < 1000; i++ {
+		assert.Equal(t, i%2 == 1, hs.Find(intKey(i)))
+	}
+	assert.Len(t, slices.Collect(hs.All()), 500)
+}
+
+func Test_GivenSwissSet_WhenKeysAreZeroValues_ThenStoredLikeAnyOther(t *testing.T) {
+	// Given
+	hs := NewSwissHashSet[StringKey]()
+
+	// When
+	err := hs.Insert(StringKey(""))
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, hs.Find(StringKey("")))
+	assert.NoError(t, hs.Delete(StringKey("")))
+	assert.False(t, hs.Find(StringKey("")))
+	assert.Equal(t, 0, hs.Count())
+}
+
+func Test_GivenSwissSetWithCollidingKeys_WhenDeletingInFullGroup_ThenKeysBehindStillFound(t *testing.T) {
+	// Given
+	hs := NewSwissHashSet[collidingKey]()
+	for i := 0; i < 12; i++ {
+		hs.Insert(collidingKey(i))
+	}
+
+	// When
+	hs.Delete(collidingKey(0))
+	hs.Delete(collidingKey(11))
+
+	// Then
+	assert.Equal(t, 1, hs.tombstones)
+	for i := 1; i < 11; i++ {
+		assert.True(t, hs.Find(collidingKey(i)))
+	}
+	assert.False(t, hs.Find(collidingKey(0)))
+	assert.NoError(t, hs.Insert(collidingKey(0)))
+	assert.Equal(t, 0, hs.tombstones)
+}
+
+func Test_GivenSwissSet_WhenGrowing_ThenGroupsDoubleAndLoadStaysBelowSevenEighths(t *testing.T) {
+	// Given
+	hs := NewSwissHashSet[IntKey]()
+
+	// When
+	for i := 0; i < 10000; i++ {
+		hs.Insert(IntKey(i))
+	}
+
+	// Then
+	groups := len(hs.ctrl)
+	assert.Equal(t, 0, groups&(groups-1))
+	assert.Len(t, hs.keys, groups*groupSize)
+	assert.LessOrEqual(t, hs.Count(), groups*groupSize*7/8)
+	assert.Greater(t, hs.Count(), groups*groupSize*7/16)
+}
+
+func Test_GivenSwissSetDeleteHeavyWorkload_WhenChurningKeys_ThenSizeStaysAndContentMatchesMap(t *testing.T) {
+	// Given
+	hs := NewSwissHashSet[intKey]()
+	expected := make(map[intKey]bool)
+	random := rand.New(rand.NewSource(7))
+	for i := 0; i < 500; i++ {
+		hs.Insert(intKey(i))
+		expected[intKey(i)] = true
+	}
+	groups := len(hs.ctrl)
+
+	// When
+	for i := 0; i < 20000; i++ {
+		key := intKey(random.Intn(1000))
+		if expected[key] {
+			assert.NoError(t, hs.Delete(key))
+			delete(expected, key)
+		} else if len(expected) < 500 {
+			assert.NoError(t, hs.Insert(key))
+			expected[key] = true
+		}
+	}
+
+	// Then
+	assert.Equal(t, groups, len(hs.ctrl))
+	assert.Equal(t, len(expected), hs.Count())
+	for key := intKey(0); key < 1000; key++ {
+		assert.Equal(t, expected[key], hs.Find(key))
+	}
+}
+
+func Test_GivenSwissSetBeingIterated_WhenInserting_ThenPanics(t *testing.T) {
+	// Given
+	hs := NewSwissHashSet[intKey]()
+	hs.Insert(1)
+	hs.Insert(2)
+
+	// When / Then
+	assert.PanicsWithValue(t, errModifiedDuringIteration, func() {
+		for key := range hs.All() {
+			hs.Insert(key + 10)
+		}
+	})
+}
+
+func Test_GivenSwissSet_WhenEnsuringCapacityThenTrimming_ThenNoResizeUpToCapacityAndShrinksAfter(t *testing.T) {
+	// Given
+	hs := NewSwissHashSet[intKey]()
+
+	// When
+	capacity := hs.EnsureCapacity(1000)
+	groups := len(hs.ctrl)
+	for i := 0; i < 1000; i++ {
+		hs.Insert(intKey(i))
+	}
+	for i := 10; i < 1000; i++ {
+		hs.Delete(intKey(i))
+	}
+	hs.TrimExcess()
+
+	// Then
+	assert.GreaterOrEqual(t, capacity, 1000)
+	assert.Equal(t, groupsForCapacity(1000), groups)
+	assert.Equal(t, groupsForCapacity(10), len(hs.ctrl))
+	assert.Equal(t, 0, hs.tombstones)
+	assert.Equal(t, 10, hs.Count())
+	for i := 0; i < 10; i++ {
+		assert.True(t, hs.Find(intKey(i)))
+	}
+	assert.Equal(t, hs.loadSize, hs.EnsureCapacity(5))
+}
+
+func Test_GivenRandomSets_WhenTakingSetOperations_ThenSwissAndDynamicSetsAgree(t *testing.T) {
+	// Given
+	random := rand.New(rand.NewSource(11))
+	firstSwiss, secondSwiss := NewSwissHashSet[intKey](), NewSwissHashSet[intKey]()
+	firstDynamic, secondDynamic := NewDynamicHashSet[intKey](), NewDynamicHashSet[intKey]()
+	for i := 0; i < 300; i++ {
+		first, second := intKey(random.Intn(400)), intKey(random.Intn(200))
+		firstSwiss.Insert(first)
+		firstDynamic.Insert(first)
+		secondSwiss.Insert(second)
+		secondDynamic.Insert(second)
+	}
+	sorted := func(hs *SwissHashSet[intKey]) []intKey {
+		keys := slices.Collect(hs.All())
+		slices.Sort(keys)
+		return keys
+	}
+
+	// When / Then
+	assert.Equal(t, sortedKeys(firstDynamic.Union(secondDynamic)), sorted(firstSwiss.Union(secondSwiss)))
+	assert.Equal(t, sortedKeys(firstDynamic.Intersect(secondDynamic)), sorted(firstSwiss.Intersect(secondSwiss)))
+	assert.Equal(t, sortedKeys(secondDynamic.Intersect(firstDynamic)), sorted(secondSwiss.Intersect(firstSwiss)))
+	assert.Equal(t, sortedKeys(firstDynamic.Difference(secondDynamic)), sorted(firstSwiss.Difference(secondSwiss)))
+	assert.Equal(t, sortedKeys(secondDynamic.Difference(firstDynamic)), sorted(secondSwiss.Difference(firstSwiss)))
+	assert.Equal(t, sortedKeys(firstDynamic.SymmetricDifference(secondDynamic)), sorted(firstSwiss.SymmetricDifference(secondSwiss)))
+	assert.True(t, firstSwiss.Intersect(secondSwiss).IsSubset(secondSwiss))
+	assert.False(t, firstSwiss.IsSubset(secondSwiss))
+	assert.True(t, firstSwiss.Union(secondSwiss).Equal(secondSwiss.Union(firstSwiss)))
+	assert.False(t, firstSwiss.Equal(secondSwiss))
+}
+
+func Test_GivenSwissSetsWhichMostlyCancelOut_WhenTakingDifferences_ThenResultsKeepPresizedTable(t *testing.T) {
+	// Given
+	first, second := NewSwissHashSet[intKey](), NewSwissHashSet[intKey]()
+	for i := 0; i < 100; i++ {
+		first.Insert(intKey(i))
+	}
+	for i := 0; i < 90; i++ {
+		second.Insert(intKey(i))
+	}
+
+	// When
+	union := first.Union(second)
+	difference := first.Difference(second)
+	symmetric := first.SymmetricDifference(second)
+
+	// Then
+	assert.Equal(t, groupsForCapacity(190), len(union.ctrl))
+	assert.Equal(t, groupsForCapacity(100), len(difference.ctrl))
+	assert.Equal(t, groupsForCapacity(190), len(symmetric.ctrl))
+	assert.Equal(t, 10, difference.Count())
+	assert.Equal(t, 10, symmetric.Count())
+	assert.Equal(t, 0, difference.tombstones+symmetric.tombstones)
+}
+
+func Test_GivenSwissSets_WhenModifyingInPlace_ThenSameResultEitherWayAndIterationInvalidated(t *testing.T) {
+	// Given
+	small, large := NewSwissHashSet[intKey](), NewSwissHashSet[intKey]()
+	for i := 0; i < 50; i++ {
+		large.Insert(intKey(i))
+	}
+	for i := 40; i < 60; i++ {
+		small.Insert(intKey(i))
+	}
+	smallCopy, largeCopy := small.Union(NewSwissHashSet[intKey]()), large.Union(NewSwissHashSet[intKey]())
+	union := small.Union(NewSwissHashSet[intKey]())
+
+	// When
+	smallCopy.IntersectWith(large)
+	largeCopy.IntersectWith(small)
+	union.UnionWith(large)
+
+	// Then
+	assert.True(t, smallCopy.Equal(largeCopy))
+	assert.Equal(t, 10, smallCopy.Count())
+	assert.Equal(t, 60, union.Count())
+	assert.PanicsWithValue(t, errModifiedDuringIteration, func() {
+		for range large.All() {
+			large.IntersectWith(small)
+		}
+	})
+}
+
+// SWISS TABLE BENCHMARKS
+
+type benchmarkSet interface {
+	Insert(key IntKey) error
+	Find(key IntKey) bool
+	Delete(key IntKey) error
+}
+
+var benchmarkSets = map[string]func() benchmarkSet{
+	"DoubleHashing": func() benchmarkSet { return NewDynamicHashSet[IntKey]() },
+	"Swiss":         func() benchmarkSet { return NewSwissHashSet[IntKey]() },
+}
+
+var benchmarkSizes = []int{1_000, 100_000, 1_000_000}
+
+func filledBenchmarkSet(newSet func() benchmarkSet, size int) benchmarkSet {
+	set := newSet()
+	for i := 0; i < size; i++ {
+		set.Insert(IntKey(i))
+	}
+	return set
+}
+
+func runSetBenchmark(b *testing.B, body func(b *testing.B, newSet func() benchmarkSet, size int)) {
+	for _, size := range benchmarkSizes {
+		for _, name := range []string{"DoubleHashing", "Swiss"} {
+			b.Run(fmt.Sprintf("%s/%d", name, size), func(b *testing.B) {
+				body(b, benchmarkSets[name], size)
+			})
+		}
+	}
+}
+
+func BenchmarkSetInsert(b *testing.B) {
+	runSetBenchmark(b, func(b *testing.B, newSet func() benchmarkSet, size int) {
+		for i := 0; i < b.N; i++ {
+			filledBenchmarkSet(newSet, size)
+		}
+	})
+}
+
+func BenchmarkSetFindHit(b *testing.B) {
+	runSetBenchmark(b, func(b *testing.B, newSet func() benchmarkSet, size int) {
+		set := filledBenchmarkSet(newSet, size)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			set.Find(IntKey(i % size))
+		}
+	})
+}
+
+func BenchmarkSetFindMiss(b *testing.B) {
+	runSetBenchmark(b, func(b *testing.B, newSet func() benchmarkSet, size int) {
+		set := filledBenchmarkSet(newSet, size)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			set.Find(IntKey(size + i))
+		}
+	})
+}
+
+func BenchmarkSetChurn(b *testing.B) {
+	runSetBenchmark(b, func(b *testing.B, newSet func() benchmarkSet, size int) {
+		set := filledBenchmarkSet(newSet, size)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			set.Delete(IntKey(i))
+			set.Insert(IntKey(size + i))
+		}
+	})
+}
+
+// CUCKOO HASHING
+
+// keys which land in the same two slots under the current seeds, found by trying the int keys one by one
+func keysInSameSlots(hs *CuckooHashSet[intKey], count int) []intKey {
+	groups := make(map[[2]int][]intKey)
+	for i := 0; ; i++ {
+		first, second := hs.positions(intKey(i))
+		group := append(groups[[2]int{first, second}], intKey(i))
+		if len(group) == count {
+			return group
+		}
+		groups[[2]int{first, second}] = group
+	}
+}
+
+func assertCuckooInvariant(t *testing.T, hs *CuckooHashSet[intKey]) {
+	stashed := 0
+	for key := range hs.All() {
+		first, second := hs.positions(key)
+		inFirst := hs.tables[0][first].used && hs.tables[0][first].key == key
+		inSecond := hs.tables[1][second].used && hs.tables[1][second].key == key
+		if !inFirst && !inSecond {
+			assert.Contains(t, hs.stash, key)
+			stashed++
+		}
+	}
+	assert.Equal(t, len(hs.stash), stashed)
+	assert.LessOrEqual(t, len(hs.stash), cuckooStashSize)
+}
+
+func Test_GivenCuckooSet_WhenInsertingFindingAndDeleting_ThenWorks(t *testing.T) {
+	// Given
+	hs := NewCuckooHashSet[intKey]()
+
+	// When
+	for i := 0; i < 1000; i++ {
+		assert.NoError(t, hs.Insert(intKey(i)))
+	}
+	errDuplicate := hs.Insert(intKey(500))
+	for i := 0; i < 1000; i += 2 {
+		assert.NoError(t, hs.Delete(intKey(i)))
+	}
+	errMissing := hs.Delete(intKey(0))
+
+	// Then
+	assert.EqualError(t, errDuplicate, "duplicate key")
+	assert.EqualError(t, errMissing, "no element found")
+	assert.Equal(t, 500, hs.Count())
+	for i := 0; i < 1000; i++ {
+		assert.Equal(t, i%2 == 1, hs.Find(intKey(i)))
+	}
+	assert.Len(t, slices.Collect(hs.All()), 500)
+}
+
+func Test_GivenCuckooSet_WhenKeyIsZeroValue_ThenStoredLikeAnyOther(t *testing.T) {
+	// Given
+	hs := NewCuckooHashSet[intKey]()
+
+	// When
+	err := hs.Insert(intKey(0))
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, hs.Find(intKey(0)))
+	assert.NoError(t, hs.Delete(intKey(0)))
+	assert.False(t, hs.Find(intKey(0)))
+}
+
+func Test_GivenManyKeys_WhenInsertedIntoCuckooSet_ThenEveryKeyInOneOfItsTwoSlotsOrStash(t *testing.T) {
+	// Given
+	hs := NewCuckooHashSet[intKey]()
+	hs.random = rand.New(rand.NewSource(3))
+	hs.allocate(cuckooInitialSize)
+
+	// When
+	for i := 0; i < 5000; i++ {
+		hs.Insert(intKey(i))
+	}
+
+	// Then
+	assert.Equal(t, 5000, hs.Count())
+	assert.LessOrEqual(t, float64(hs.Count()), CuckooLoadFactor*float64(2*len(hs.tables[0])))
+	assertCuckooInvariant(t, hs)
+}
+
+func Test_GivenKeysInSameSlots_WhenBothSlotsTaken_ThenStashUsed(t *testing.T) {
+	// Given
+	hs := NewCuckooHashSet[intKey]()
+	keys := keysInSameSlots(hs, 2+cuckooStashSize)
+	seeds := hs.seeds
+
+	// When
+	for _, key := range keys {
+		assert.NoError(t, hs.Insert(key))
+	}
+
+	// Then
+	assert.Equal(t, seeds, hs.seeds)
+	assert.Equal(t, keys[2:], hs.stash)
+	for _, key := range keys {
+		assert.True(t, hs.Find(key))
+	}
+	assert.NoError(t, hs.Delete(keys[3]))
+	assert.False(t, hs.Find(keys[3]))
+	assert.Len(t, hs.stash, cuckooStashSize-1)
+}
+
+func Test_GivenFullStash_WhenCycleOccurs_ThenRehashedWithNewSeeds(t *testing.T) {
+	// Given
+	hs := NewCuckooHashSet[intKey]()
+	keys := keysInSameSlots(hs, 3+cuckooStashSize)
+	seeds := hs.seeds
+	for _, key := range keys[:len(keys)-1] {
+		hs.Insert(key)
+	}
+
+	// When
+	err := hs.Insert(keys[len(keys)-1])
+
+	// Then
+	assert.NoError(t, err)
+	assert.NotEqual(t, seeds, hs.seeds)
+	assert.Equal(t, len(keys), hs.Count())
+	for _, key := range keys {
+		assert.True(t, hs.Find(key))
+	}
+	assertCuckooInvariant(t, hs)
+}
+
+func Test_GivenKeysWithEqualHashCodes_WhenNoSeedHelps_ThenErrorAndSetUnchanged(t *testing.T) {
+	// Given
+	hs := NewCuckooHashSet[collidingKey]()
+	for i := 0; i < 2+cuckooStashSize; i++ {
+		assert.NoError(t, hs.Insert(collidingKey(i)))
+	}
+
+	// When
+	err := hs.Insert(collidingKey(100))
+
+	// Then
+	assert.EqualError(t, err, "too many keys with colliding hash codes")
+	assert.Equal(t, 2+cuckooStashSize, hs.Count())
+	assert.False(t, hs.Find(collidingKey(100)))
+	for i := 0; i < 2+cuckooStashSize; i++ {
+		assert.True(t, hs.Find(collidingKey(i)))
+	}
+}
+
+func Test_GivenFullTables_WhenEvictionChainFails_ThenTablesRestored(t *testing.T) {
+	// Given
+	hs := NewCuckooHashSet[intKey]()
+	keys := keysInSameSlots(hs, 3)
+	hs.Insert(keys[0])
+	hs.Insert(keys[1])
+	tables := [2][]cuckooSlot[intKey]{slices.Clone(hs.tables[0]), slices.Clone(hs.tables[1])}
+
+	// When
+	placed := hs.place(keys[2])
+
+	// Then
+	assert.False(t, placed)
+	assert.Equal(t, tables, hs.tables)
+}
+
+func Test_GivenCuckooSetBeingIterated_WhenDeleting_ThenPanics(t *testing.T) {
+	// Given
+	hs := NewCuckooHashSet[intKey]()
+	hs.Insert(1)
+	hs.Insert(2)
+
+	// When / Then
+	assert.PanicsWithValue(t, errModifiedDuringIteration, func() {
+		for key := range hs.All() {
+			hs.Delete(key)
+		}
+	})
+}
+
+func Test_GivenCuckooSet_WhenChurningKeys_ThenSameContentAsMap(t *testing.T) {
+	// Given
+	hs := NewCuckooHashSet[intKey]()
+	expected := make(map[intKey]bool)
+	random := rand.New(rand.NewSource(11))
+
+	// When
+	for i := 0; i < 20000; i++ {
+		key := intKey(random.Intn(3000))
+		if random.Intn(3) == 0 {
+			assert.Equal(t, expected[key], hs.Delete(key) == nil)
+			delete(expected, key)
+		} else {
+			assert.Equal(t, !expected[key], hs.Insert(key) == nil)
+			expected[key] = true
+		}
+	}
+
+	// Then
+	assert.Equal(t, len(expected), hs.Count())
+	for key := intKey(0); key < 3000; key++ {
+		assert.Equal(t, expected[key], hs.Find(key))
+	}
+	assertCuckooInvariant(t, hs)
+}
+
+func Test_GivenIncrementalSetMigrating_WhenFailedInsertAndDeleteDuringIteration_ThenEveryKeyYieldedOnce(t *testing.T) {
+	// Given
+	hs := NewIncrementalDynamicHashSet[intKey]()
+	i := 0
+	for hs.oldSlots == nil {
+		hs.Insert(intKey(i))
+		i++
+	}
+	oldCount := hs.oldCount
+
+	// When
+	var yielded []intKey
+	assert.NotPanics(t, func() {
+		for key := range hs.All() {
+			yielded = append(yielded, key)
+			assert.EqualError(t, hs.Insert(key), "duplicate key")
+			assert.EqualError(t, hs.Delete(intKey(-1)), "no element found")
+		}
+	})
+	slices.Sort(yielded)
+
+	// Then
+	assert.Equal(t, oldCount, hs.oldCount)
+	assert.Len(t, yielded, i)
+	for key := 0; key < i; key++ {
+		assert.Equal(t, intKey(key), yielded[key])
+	}
+}
@@ -2,61 +2,157 @@ package hashtable
 
 import (
 	// "strconv"
-	"math"
+	"math/bits"
 	// "os"
 )
 
 type HashTable struct {
-	size  int
-	step  int
-	slots []string
+	size     int
+	step     int
+	slots    []string
 	occupied []bool
+	deleted  []bool
+	count    int
+	removed  int
+	maxLoad  float64
+	seed     uint64
+	seeded   bool
 }
 
 func Init(sz int, stp int) HashTable {
 	ht := HashTable{size: sz, step: stp, slots: nil}
 	ht.slots = make([]string, sz)
 	ht.occupied = make([]bool, sz)
+	ht.deleted = make([]bool, sz)
+	return ht
+}
+
+// same as Init, but the table grows when after the next Put more than maxLoad of the slots would be used. Tombstones
+// count as used, because they make the probe chains longer just like live values. The size is rounded up to a prime which
+// does not divide the step - otherwise the step walks only a part of the slots and Put fails with most of the table free
+func InitGrowing(sz int, stp int, maxLoad float64) HashTable {
+	ht := Init(coprimeSize(sz, stp), stp)
+	ht.maxLoad = maxLoad
+	return ht
+}
+
+// same as Init, but the slot of a value depends on the seed, see SeededPolyHash. Every seed is a valid one, 0 included -
+// whether the table is seeded is kept separately, so InitSeeded(sz, stp, 0) does not fall back to the plain PolyHash
+func InitSeeded(sz int, stp int, seed uint64) HashTable {
+	ht := Init(sz, stp)
+	ht.seed = seed
+	ht.seeded = true
 	return ht
 }
 
 func (ht *HashTable) HashFun(value string) int {
+	if ht.seeded {
+		return SeededPolyHash(value, ht.seed, len(ht.slots))
+	}
+
+	return PolyHash(value, len(ht.slots))
+}
+
+/*
+* The hash is still the sum of value[i] * 26^(len - i), but computed with the Horner scheme and the modulo applied after every
+* step - ((value[0] * 26 + value[1]) * 26 + ...) * 26. Before we computed 26^(len - i) itself, through float64 in HashTable
+* and through int in NativeDictionary, which after ~13 characters loses precision or overflows, so long keys with a common
+* suffix ended up in the same few slots. Now the intermediate result is always below size, so nothing can overflow and for short
+* keys the slots stay exactly the same as before.
+*/
+
+func PolyHash(value string, size int) int {
 	result := 0
 
 	for i := 0; i < len(value); i++ {
-		result += (int(value[i]) * int(math.Pow(26, float64(len(value)-i)))) % len(ht.slots)
+		result = (result + int(value[i])) * 26 % size
 	}
-	
-	return result % len(ht.slots)
+
+	return result
+}
+
+/*
+* The seeded variant does not use the fixed 26 - the base is derived from the seed and the polynomial is computed modulo the
+* Mersenne prime 2^61 - 1, only the final value is reduced to the table size. With a base nobody knows in advance it is hard to
+* pick keys which all land in the same slot. The product of two 61 bit numbers needs 122 bits, so bits.Mul64 gives us both
+* halves and because 2^61 = 1 mod p we can fold the high part back without a division.
+*/
+
+const mersenne61 = 1<<61 - 1
+
+func SeededPolyHash(value string, seed uint64, size int) int {
+	base := seed%(mersenne61-2) + 2
+	result := uint64(0)
+
+	for i := 0; i < len(value); i++ {
+		result = mulMod61(result, base) + uint64(value[i]) + 1
+		if result >= mersenne61 {
+			result -= mersenne61
+		}
+	}
+
+	return int(result % uint64(size))
+}
+
+func mulMod61(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	result := (hi<<3 | lo>>61) + (lo & mersenne61)
+	result = (result & mersenne61) + (result >> 61)
+
+	if result >= mersenne61 {
+		result -= mersenne61
+	}
+
+	return result
 }
 
 func (ht *HashTable) SeekSlot(value string) int {
 	idx := ht.HashFun(value)
 	startIdx := idx
+	firstDeleted := -1
 
-	for ht.occupied[idx] {
-		if ht.slots[idx] == value {
+	for ht.occupied[idx] || ht.deleted[idx] {
+		if ht.occupied[idx] && ht.slots[idx] == value {
 			return -1
 		}
 
+		if ht.deleted[idx] && firstDeleted == -1 {
+			firstDeleted = idx
+		}
+
 		idx = (idx + ht.step) % len(ht.slots)
 
 		if idx == startIdx {
-			return -1
+			return firstDeleted
 		}
 	}
+
+	if firstDeleted != -1 {
+		return firstDeleted
+	}
+
 	return idx
 }
 
 func (ht *HashTable) Put(value string) int {
+	if ht.needsGrowth() {
+		ht.grow()
+	}
+
 	idx := ht.SeekSlot(value)
 
 	if idx == -1 {
 		return idx
 	}
 
+	if ht.deleted[idx] {
+		ht.deleted[idx] = false
+		ht.removed--
+	}
+
 	ht.slots[idx] = value
 	ht.occupied[idx] = true
+	ht.count++
 
 	return idx
 }
@@ -66,6 +162,10 @@ func (ht *HashTable) Find(value string) int {
 	fastIdx := (slowIdx + ht.step) % len(ht.slots)
 
 	for (!ht.occupied[slowIdx] || ht.slots[slowIdx] != value) && slowIdx != fastIdx {
+		// never used slot - Put would have stopped here, so the value can not be further in the chain
+		if !ht.occupied[slowIdx] && !ht.deleted[slowIdx] {
+			return -1
+		}
 		slowIdx = (slowIdx + ht.step) % len(ht.slots)
 		fastIdx = ((fastIdx+ht.step)%len(ht.slots) + ht.step) % len(ht.slots)
 	}
@@ -76,3 +176,59 @@ func (ht *HashTable) Find(value string) int {
 
 	return -1
 }
+
+// index the value was removed from or -1. The slot becomes a tombstone so that values put after it in the same
+// chain are still found
+func (ht *HashTable) Delete(value string) int {
+	idx := ht.Find(value)
+
+	if idx == -1 {
+		return idx
+	}
+
+	ht.slots[idx] = ""
+	ht.occupied[idx] = false
+	ht.deleted[idx] = true
+	ht.count--
+	ht.removed++
+
+	return idx
+}
+
+func (ht *HashTable) Count() int {
+	return ht.count
+}
+
+func (ht *HashTable) needsGrowth() bool {
+	return ht.maxLoad > 0 && float64(ht.count+ht.removed+1) > ht.maxLoad*float64(len(ht.slots))
+}
+
+// if only the tombstones pushed us over the threshold it is enough to rehash into the same size, otherwise we take the
+// next prime after the doubled size which does not divide the step, so that the step still visits every slot
+func (ht *HashTable) grow() {
+	newSize, seed, seeded := len(ht.slots), ht.seed, ht.seeded
+
+	if float64(ht.count+1) > ht.maxLoad*float64(newSize) {
+		newSize = 2 * newSize
+	}
+
+	oldSlots, oldOccupied := ht.slots, ht.occupied
+	*ht = InitGrowing(newSize, ht.step, ht.maxLoad)
+	ht.seed, ht.seeded = seed, seeded
+
+	for i := range oldSlots {
+		if oldOccupied[i] {
+			ht.Put(oldSlots[i])
+		}
+	}
+}
+
+// the smallest prime not below min which does not divide the step. For a prime size that is the same as gcd(step, size) == 1,
+// so the probe sequence goes through every slot before it comes back to the start
+func coprimeSize(min int, step int) int {
+	size := getPrime(min)
+	for step%size == 0 {
+		size = getPrime(size + 1)
+	}
+	return size
+}
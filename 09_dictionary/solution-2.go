@@ -3,6 +3,9 @@ package dictionary
 import (
 	"constraints"
 	"errors"
+	"iter"
+	"slices"
+
 	"github.com/vernon-gant/algos1-go/07_ordered_list"
 )
 
@@ -24,20 +27,83 @@ import (
 * FindPosition.
  */
 
+/*
+* 9. Dictionary - task number 5 continuation - the spec as an interface
+*
+* What I wrote above about the spec is now actually in the code. OrderedSet is everything the dictionary needs from the keys -
+* the four operations plus iteration in both directions, forward also from a given position. The OrderedList from lesson 7
+* satisfies it, next to it we have a sorted array where FindPosition is a binary search and the skip list from lesson 7 with
+* expected O(log(n)) for all of them.
+* The backend is chosen in NewOrderedDict with an option, the linked list stays the default. The dictionary creates the backend
+* itself, so it is always empty and ascending - otherwise the positions would not match the values slice.
+*/
+
+type OrderedSet[K constraints.Ordered] interface {
+	Add(key K)
+	Delete(key K)
+	FindPosition(key K) (int, bool)
+	Count() int
+	All() iter.Seq[K]
+	AllFrom(position int) iter.Seq[K]
+	Backward() iter.Seq[K]
+}
+
+// backends which can jump to a position directly, Select uses it instead of walking the keys
+type indexedSet[K constraints.Ordered] interface {
+	GetByIndex(index int) (K, error)
+}
+
+type Backend int
+
+const (
+	LinkedListBackend Backend = iota
+	SortedArrayBackend
+	SkipListBackend
+)
+
+type OrderedDictOption func(*orderedDictConfig)
+
+type orderedDictConfig struct {
+	backend Backend
+}
+
+func WithBackend(backend Backend) OrderedDictOption {
+	return func(config *orderedDictConfig) {
+		config.backend = backend
+	}
+}
+
 type OrderedDict[K constraints.Ordered, V any] struct {
-	keys   *ordered_list.OrderedList[K]
+	keys   OrderedSet[K]
 	values []V
 }
 
-func NewOrderedDict[K constraints.Ordered, V any]() *OrderedDict[K, V] {
-	keys := &ordered_list.OrderedList[K]{}
-	keys.Clear(true)
+func NewOrderedDict[K constraints.Ordered, V any](options ...OrderedDictOption) *OrderedDict[K, V] {
+	config := orderedDictConfig{backend: LinkedListBackend}
+
+	for _, option := range options {
+		option(&config)
+	}
+
 	return &OrderedDict[K, V]{
-		keys:   keys,
+		keys:   newOrderedSet[K](config.backend),
 		values: make([]V, 0),
 	}
 }
 
+func newOrderedSet[K constraints.Ordered](backend Backend) OrderedSet[K] {
+	switch backend {
+	case SortedArrayBackend:
+		return &SortedArray[K]{}
+	case SkipListBackend:
+		return ordered_list.NewSkipList[K](true)
+	default:
+		keys := &ordered_list.OrderedList[K]{}
+		keys.Clear(true)
+		return keys
+	}
+}
+
 func (d *OrderedDict[K, V]) Put(key K, value V) {
 	position, found := d.keys.FindPosition(key)
 
@@ -75,6 +141,232 @@ func (d *OrderedDict[K, V]) IsKey(key K) bool {
 	return found
 }
 
+// key value pairs in the key order, values are taken from the parallel slice by the position of the key
+func (d *OrderedDict[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		position := 0
+		for key := range d.keys.All() {
+			if !yield(key, d.values[position]) {
+				return
+			}
+			position++
+		}
+	}
+}
+
+func (d *OrderedDict[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		position := len(d.values) - 1
+		for key := range d.keys.Backward() {
+			if !yield(key, d.values[position]) {
+				return
+			}
+			position--
+		}
+	}
+}
+
+/*
+* 9. Dictionary - task number 5 continuation - ordered queries
+*
+* The whole point of keeping the keys sorted is that we can ask more than "is it there". Everything below stands on FindPosition -
+* for a missing key the position is where it would be inserted, which in the ascending list is exactly the number of smaller keys.
+* So Rank is FindPosition itself, Floor is one position to the left of a missing key and Ceiling is the position itself. The values
+* come from the parallel slice by position as in Put, only the key at some position has to be walked to through the list, because
+* OrderedList has no indexing. The sorted array and the skip list have GetByIndex, so Select takes the shortcut for them.
+*/
+
+func (d *OrderedDict[K, V]) Get(key K) (V, error) {
+	var result V
+	position, found := d.keys.FindPosition(key)
+
+	if !found {
+		return result, errors.New("key not found")
+	}
+
+	return d.values[position], nil
+}
+
+// the greatest key less than or equal to the given one
+func (d *OrderedDict[K, V]) Floor(key K) (K, V, error) {
+	position, found := d.keys.FindPosition(key)
+
+	if found {
+		return key, d.values[position], nil
+	}
+
+	if position == 0 {
+		var zeroKey K
+		var zeroValue V
+		return zeroKey, zeroValue, errors.New("no key less than or equal to the given one")
+	}
+
+	return d.Select(position - 1)
+}
+
+// the least key greater than or equal to the given one
+func (d *OrderedDict[K, V]) Ceiling(key K) (K, V, error) {
+	position, found := d.keys.FindPosition(key)
+
+	if found {
+		return key, d.values[position], nil
+	}
+
+	if position == d.keys.Count() {
+		var zeroKey K
+		var zeroValue V
+		return zeroKey, zeroValue, errors.New("no key greater than or equal to the given one")
+	}
+
+	return d.Select(position)
+}
+
+func (d *OrderedDict[K, V]) Min() (K, V, error) {
+	return d.Select(0)
+}
+
+func (d *OrderedDict[K, V]) Max() (K, V, error) {
+	return d.Select(d.keys.Count() - 1)
+}
+
+// key value pairs with lo <= key <= hi in the key order, both bounds inclusive. The iteration starts right at the position
+// of lo and stops at the first key above hi, so with the sorted array or the skip list it costs O(log(n)) plus the pairs yielded
+func (d *OrderedDict[K, V]) Range(lo K, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		position, _ := d.keys.FindPosition(lo)
+		for key := range d.keys.AllFrom(position) {
+			if key > hi || !yield(key, d.values[position]) {
+				return
+			}
+			position++
+		}
+	}
+}
+
+// number of keys less than the given one, the key itself does not have to be in the dictionary
+func (d *OrderedDict[K, V]) Rank(key K) int {
+	position, _ := d.keys.FindPosition(key)
+	return position
+}
+
+// key value pair with the given rank, so Select(Rank(key)) returns the key back if it is present
+func (d *OrderedDict[K, V]) Select(position int) (K, V, error) {
+	var zeroKey K
+	var zeroValue V
+
+	if position < 0 || position >= d.keys.Count() {
+		return zeroKey, zeroValue, errors.New("position out of range")
+	}
+
+	if indexed, ok := d.keys.(indexedSet[K]); ok {
+		key, err := indexed.GetByIndex(position)
+		return key, d.values[position], err
+	}
+
+	current := 0
+	for key := range d.keys.All() {
+		if current == position {
+			return key, d.values[position], nil
+		}
+		current++
+	}
+
+	return zeroKey, zeroValue, errors.New("position out of range")
+}
+
+/*
+* 9. Dictionary - task number 5 continuation - sorted array backend
+*
+* The simplest O(log(n)) search - keys in a slice and a binary search for the first key which is not less than the given one.
+* Add and Delete still shift the tail of the slice, but the dictionary shifts its values anyway, so nothing is lost.
+*/
+
+type SortedArray[K constraints.Ordered] struct {
+	keys    []K
+	version int
+}
+
+func (a *SortedArray[K]) Count() int {
+	return len(a.keys)
+}
+
+func (a *SortedArray[K]) Add(key K) {
+	position, _ := a.FindPosition(key)
+	a.keys = slices.Insert(a.keys, position, key)
+	a.version++
+}
+
+func (a *SortedArray[K]) Delete(key K) {
+	position, found := a.FindPosition(key)
+
+	if !found {
+		return
+	}
+
+	a.keys = slices.Delete(a.keys, position, position+1)
+	a.version++
+}
+
+func (a *SortedArray[K]) FindPosition(key K) (int, bool) {
+	low, high := 0, len(a.keys)
+
+	for low < high {
+		middle := low + (high-low)/2
+
+		if a.keys[middle] < key {
+			low = middle + 1
+		} else {
+			high = middle
+		}
+	}
+
+	return low, low < len(a.keys) && a.keys[low] == key
+}
+
+func (a *SortedArray[K]) GetByIndex(index int) (K, error) {
+	var zero K
+
+	if index < 0 || index >= len(a.keys) {
+		return zero, errors.New("index out of range")
+	}
+
+	return a.keys[index], nil
+}
+
+func (a *SortedArray[K]) All() iter.Seq[K] {
+	return a.AllFrom(0)
+}
+
+func (a *SortedArray[K]) AllFrom(position int) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		version := a.version
+		for _, key := range a.keys[min(max(position, 0), len(a.keys)):] {
+			if !yield(key) {
+				return
+			}
+			a.checkVersion(version)
+		}
+	}
+}
+
+func (a *SortedArray[K]) Backward() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		version := a.version
+		for i := len(a.keys) - 1; i >= 0; i-- {
+			if !yield(a.keys[i]) {
+				return
+			}
+			a.checkVersion(version)
+		}
+	}
+}
+
+func (a *SortedArray[K]) checkVersion(version int) {
+	if a.version != version {
+		panic(errModifiedDuringIteration)
+	}
+}
+
 /*
 * 9. Dictionary - task number 6 - dictionary for fixed length bit strings
 *
@@ -1,9 +1,13 @@
 package dictionary
 
 import (
+	"fmt"
+	"iter"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vernon-gant/algos1-go/08_hash_table"
 )
 
 // PUT TESTS
@@ -251,4 +255,646 @@ func Test_GivenDict_WhenMixingOperations_ThenBehavesConsistently(t *testing.T) {
 	val2, err2 := dict.Get("x")
 	assert.NoError(t, err2)
 	assert.Equal(t, "updated-x", val2)
-}
\ No newline at end of file
+}
+
+// ALL TESTS
+
+func Test_GivenDictWithKeys_WhenRangingOverAll_ThenEveryPairYielded(t *testing.T) {
+	// Given
+	dict := Init[int](17)
+	dict.Put("one", 1)
+	dict.Put("two", 2)
+	dict.Put("three", 3)
+
+	// When
+	pairs := map[string]int{}
+	for k, v := range dict.All() {
+		pairs[k] = v
+	}
+
+	// Then
+	assert.Equal(t, map[string]int{"one": 1, "two": 2, "three": 3}, pairs)
+}
+
+func Test_GivenOrderedDict_WhenRangingOverAll_ThenPairsInKeyOrder(t *testing.T) {
+	// Given
+	dict := NewOrderedDict[int, string]()
+	dict.Put(3, "c")
+	dict.Put(1, "a")
+	dict.Put(2, "b")
+
+	// When
+	var keys []int
+	var values []string
+	for k, v := range dict.All() {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+
+	// Then
+	assert.Equal(t, []int{1, 2, 3}, keys)
+	assert.Equal(t, []string{"a", "b", "c"}, values)
+}
+
+func Test_GivenOrderedDict_WhenRangingOverBackward_ThenPairsInReverseKeyOrder(t *testing.T) {
+	// Given
+	dict := NewOrderedDict[int, string]()
+	dict.Put(3, "c")
+	dict.Put(1, "a")
+	dict.Put(2, "b")
+
+	// When
+	var keys []int
+	var values []string
+	for k, v := range dict.Backward() {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+
+	// Then
+	assert.Equal(t, []int{3, 2, 1}, keys)
+	assert.Equal(t, []string{"c", "b", "a"}, values)
+}
+
+// HASH FUN TESTS
+
+func Test_GivenLongKeysWithCommonPrefix_WhenHashing_ThenSlotsStayBalanced(t *testing.T) {
+	// Given
+	dict := Init[int](17)
+	counts := make([]int, 17)
+
+	// When
+	for i := 0; i < 17*200; i++ {
+		counts[dict.HashFun(fmt.Sprintf("%032d-customer-session-identifier", i))]++
+	}
+
+	// Then
+	for slot, count := range counts {
+		assert.Greater(t, count, 100, "slot %d", slot)
+		assert.Less(t, count, 300, "slot %d", slot)
+	}
+}
+
+func Test_GivenSeededDict_WhenPuttingLongKeys_ThenAllAccessible(t *testing.T) {
+	// Given
+	dict := InitSeeded[int](31, 12345)
+
+	// When
+	for i := 0; i < 20; i++ {
+		dict.Put(fmt.Sprintf("%032d-customer-session-identifier", i), i)
+	}
+
+	// Then
+	for i := 0; i < 20; i++ {
+		val, err := dict.Get(fmt.Sprintf("%032d-customer-session-identifier", i))
+		assert.NoError(t, err)
+		assert.Equal(t, i, val)
+	}
+}
+
+func Test_GivenSeedZero_WhenHashing_ThenSeededHashUsed(t *testing.T) {
+	// Given
+	dict := InitSeeded[int](31, 0)
+
+	// When
+	dict.Put("customer-session-identifier", 1)
+
+	// Then
+	for i := 0; i < 20; i++ {
+		key := fmt.Sprintf("%032d-customer-session-identifier", i)
+		assert.Equal(t, hashtable.SeededPolyHash(key, 0, 31), dict.HashFun(key))
+	}
+	value, err := dict.Get("customer-session-identifier")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, value)
+}
+
+
+// GROWTH TESTS
+
+func Test_GivenSmallDict_WhenPuttingMoreKeysThanSlots_ThenGrowsAndKeepsAll(t *testing.T) {
+	// Given
+	dict := Init[int](5)
+
+	// When
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, dict.Put(fmt.Sprintf("key-%d", i), i))
+	}
+
+	// Then
+	assert.Equal(t, 100, dict.Len())
+	assert.Greater(t, len(dict.slots), 100)
+	assert.Equal(t, len(dict.slots), dict.size)
+	for i := 0; i < 100; i++ {
+		val, err := dict.Get(fmt.Sprintf("key-%d", i))
+		assert.NoError(t, err)
+		assert.Equal(t, i, val)
+	}
+}
+
+func Test_GivenDictWithoutSlots_WhenPutting_ThenGrowsAndKeepsEveryKey(t *testing.T) {
+	// Given
+	dict := Init[int](0)
+
+	// When
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, dict.Put(fmt.Sprintf("key-%d", i), i))
+	}
+
+	// Then
+	assert.Equal(t, 10, dict.Len())
+	assert.Equal(t, len(dict.slots), dict.size)
+	for i := 0; i < 10; i++ {
+		val, err := dict.Get(fmt.Sprintf("key-%d", i))
+		assert.NoError(t, err)
+		assert.Equal(t, i, val)
+	}
+}
+
+func Test_GivenDictWithKey_WhenUpdating_ThenLenUnchanged(t *testing.T) {
+	// Given
+	dict := Init[int](17)
+	dict.Put("a", 1)
+
+	// When
+	err := dict.Put("a", 2)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 1, dict.Len())
+}
+
+// DELETE TESTS
+
+func Test_GivenDictWithKey_WhenDeleting_ThenKeyGone(t *testing.T) {
+	// Given
+	dict := Init[int](17)
+	dict.Put("a", 1)
+	dict.Put("b", 2)
+
+	// When
+	err := dict.Delete("a")
+
+	// Then
+	assert.NoError(t, err)
+	assert.False(t, dict.IsKey("a"))
+	assert.True(t, dict.IsKey("b"))
+	assert.Equal(t, 1, dict.Len())
+}
+
+func Test_GivenDict_WhenDeletingMissingKey_ThenErrorReturned(t *testing.T) {
+	// Given
+	dict := Init[int](17)
+	dict.Put("a", 1)
+
+	// When
+	err := dict.Delete("b")
+
+	// Then
+	assert.Error(t, err)
+	assert.Equal(t, 1, dict.Len())
+}
+
+func Test_GivenCluster_WhenDeletingItsFirstKey_ThenKeysBehindItShiftedBackAndFound(t *testing.T) {
+	// Given - "a" and "f" share the home slot in a table of 5
+	dict := Init[string](5)
+	dict.Put("a", "A")
+	dict.Put("f", "F")
+	home := dict.HashFun("a")
+
+	// When
+	err := dict.Delete("a")
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "f", dict.slots[home])
+	assert.False(t, dict.occupied[(home+1)%5])
+	val, err := dict.Get("f")
+	assert.NoError(t, err)
+	assert.Equal(t, "F", val)
+}
+
+func Test_GivenManyKeys_WhenDeletingEveryOther_ThenRemainingKeysStillFound(t *testing.T) {
+	// Given
+	dict := Init[int](7)
+	for i := 0; i < 200; i++ {
+		dict.Put(fmt.Sprintf("k%d", i), i)
+	}
+
+	// When
+	for i := 0; i < 200; i += 2 {
+		assert.NoError(t, dict.Delete(fmt.Sprintf("k%d", i)))
+	}
+
+	// Then
+	assert.Equal(t, 100, dict.Len())
+	for i := 0; i < 200; i++ {
+		val, err := dict.Get(fmt.Sprintf("k%d", i))
+		if i%2 == 0 {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, i, val)
+		}
+	}
+}
+
+func Test_GivenWrappingCluster_WhenDeleting_ThenKeysAcrossTheEndStillFound(t *testing.T) {
+	// Given
+	dict := Init[int](61)
+	var keys []string
+	for i := 0; len(keys) < 4; i++ {
+		key := fmt.Sprintf("w%d", i)
+		if dict.HashFun(key) == 60 {
+			keys = append(keys, key)
+		}
+	}
+	for i, key := range keys {
+		dict.Put(key, i)
+	}
+
+	// When
+	dict.Delete(keys[0])
+
+	// Then
+	for i, key := range keys[1:] {
+		val, err := dict.Get(key)
+		assert.NoError(t, err)
+		assert.Equal(t, i+1, val)
+	}
+	assert.Equal(t, 3, dict.Len())
+}
+
+// KEYS TESTS
+
+func Test_GivenDict_WhenRangingOverKeys_ThenEveryLiveKeyYielded(t *testing.T) {
+	// Given
+	dict := Init[int](17)
+	dict.Put("a", 1)
+	dict.Put("b", 2)
+	dict.Put("c", 3)
+	dict.Delete("b")
+
+	// When
+	keys := slices.Collect(dict.Keys())
+
+	// Then
+	slices.Sort(keys)
+	assert.Equal(t, []string{"a", "c"}, keys)
+}
+
+func Test_GivenDictIteration_WhenOverwritingValues_ThenNoPanicAndValuesUpdated(t *testing.T) {
+	// Given
+	dict := Init[int](17)
+	dict.Put("a", 1)
+	dict.Put("b", 2)
+
+	// When
+	assert.NotPanics(t, func() {
+		for key := range dict.Keys() {
+			value, _ := dict.Get(key)
+			dict.Put(key, value*10)
+		}
+	})
+
+	// Then
+	a, _ := dict.Get("a")
+	b, _ := dict.Get("b")
+	assert.Equal(t, 10, a)
+	assert.Equal(t, 20, b)
+	assert.Equal(t, 2, dict.Len())
+}
+
+func Test_GivenDictIteration_WhenDeleting_ThenPanics(t *testing.T) {
+	// Given
+	dict := Init[int](17)
+	dict.Put("a", 1)
+	dict.Put("b", 2)
+
+	// When/Then
+	assert.PanicsWithError(t, errModifiedDuringIteration.Error(), func() {
+		for key := range dict.Keys() {
+			dict.Delete(key)
+		}
+	})
+}
+
+// ORDERED DICT QUERIES TESTS
+
+func newOrderedDictOfTens() *OrderedDict[int, string] {
+	dict := NewOrderedDict[int, string]()
+	for _, key := range []int{30, 10, 50, 20, 40} {
+		dict.Put(key, fmt.Sprintf("v%d", key))
+	}
+	return dict
+}
+
+func Test_GivenOrderedDict_WhenGettingExistingKey_ThenValueReturned(t *testing.T) {
+	// Given
+	dict := newOrderedDictOfTens()
+
+	// When
+	value, err := dict.Get(40)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "v40", value)
+}
+
+func Test_GivenOrderedDict_WhenGettingMissingKey_ThenError(t *testing.T) {
+	// Given
+	dict := newOrderedDictOfTens()
+
+	// When
+	_, err := dict.Get(35)
+
+	// Then
+	assert.Error(t, err)
+}
+
+func Test_GivenOrderedDict_WhenTakingFloor_ThenGreatestKeyNotAbove(t *testing.T) {
+	// Given
+	dict := newOrderedDictOfTens()
+
+	// When
+	exactKey, exactValue, exactErr := dict.Floor(20)
+	betweenKey, betweenValue, betweenErr := dict.Floor(39)
+	aboveKey, _, aboveErr := dict.Floor(1000)
+	_, _, belowErr := dict.Floor(9)
+
+	// Then
+	assert.NoError(t, exactErr)
+	assert.Equal(t, 20, exactKey)
+	assert.Equal(t, "v20", exactValue)
+	assert.NoError(t, betweenErr)
+	assert.Equal(t, 30, betweenKey)
+	assert.Equal(t, "v30", betweenValue)
+	assert.NoError(t, aboveErr)
+	assert.Equal(t, 50, aboveKey)
+	assert.Error(t, belowErr)
+}
+
+func Test_GivenOrderedDict_WhenTakingCeiling_ThenLeastKeyNotBelow(t *testing.T) {
+	// Given
+	dict := newOrderedDictOfTens()
+
+	// When
+	exactKey, _, exactErr := dict.Ceiling(50)
+	betweenKey, betweenValue, betweenErr := dict.Ceiling(31)
+	belowKey, _, belowErr := dict.Ceiling(-5)
+	_, _, aboveErr := dict.Ceiling(51)
+
+	// Then
+	assert.NoError(t, exactErr)
+	assert.Equal(t, 50, exactKey)
+	assert.NoError(t, betweenErr)
+	assert.Equal(t, 40, betweenKey)
+	assert.Equal(t, "v40", betweenValue)
+	assert.NoError(t, belowErr)
+	assert.Equal(t, 10, belowKey)
+	assert.Error(t, aboveErr)
+}
+
+func Test_GivenOrderedDict_WhenTakingMinAndMax_ThenExtremesReturned(t *testing.T) {
+	// Given
+	dict := newOrderedDictOfTens()
+
+	// When
+	minKey, minValue, minErr := dict.Min()
+	maxKey, maxValue, maxErr := dict.Max()
+
+	// Then
+	assert.NoError(t, minErr)
+	assert.Equal(t, 10, minKey)
+	assert.Equal(t, "v10", minValue)
+	assert.NoError(t, maxErr)
+	assert.Equal(t, 50, maxKey)
+	assert.Equal(t, "v50", maxValue)
+}
+
+func Test_GivenEmptyOrderedDict_WhenTakingMinAndMax_ThenError(t *testing.T) {
+	// Given
+	dict := NewOrderedDict[int, string]()
+
+	// When
+	_, _, minErr := dict.Min()
+	_, _, maxErr := dict.Max()
+
+	// Then
+	assert.Error(t, minErr)
+	assert.Error(t, maxErr)
+}
+
+func Test_GivenOrderedDict_WhenRangingBetweenBounds_ThenOnlyKeysInsideInclusive(t *testing.T) {
+	// Given
+	dict := newOrderedDictOfTens()
+
+	// When
+	var keys []int
+	var values []string
+	for k, v := range dict.Range(20, 40) {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+
+	// Then
+	assert.Equal(t, []int{20, 30, 40}, keys)
+	assert.Equal(t, []string{"v20", "v30", "v40"}, values)
+}
+
+func Test_GivenOrderedDict_WhenRangingWithBoundsBetweenKeysOrReversed_ThenMatchingKeysOnly(t *testing.T) {
+	// Given
+	dict := newOrderedDictOfTens()
+
+	// When
+	var between []int
+	for k := range dict.Range(15, 45) {
+		between = append(between, k)
+	}
+	reversed := 0
+	for range dict.Range(40, 20) {
+		reversed++
+	}
+
+	// Then
+	assert.Equal(t, []int{20, 30, 40}, between)
+	assert.Equal(t, 0, reversed)
+}
+
+func Test_GivenOrderedDict_WhenRanking_ThenNumberOfSmallerKeys(t *testing.T) {
+	// Given
+	dict := newOrderedDictOfTens()
+
+	// When/Then
+	assert.Equal(t, 0, dict.Rank(5))
+	assert.Equal(t, 0, dict.Rank(10))
+	assert.Equal(t, 2, dict.Rank(30))
+	assert.Equal(t, 3, dict.Rank(35))
+	assert.Equal(t, 5, dict.Rank(60))
+}
+
+func Test_GivenOrderedDict_WhenSelecting_ThenPairAtRankReturned(t *testing.T) {
+	// Given
+	dict := newOrderedDictOfTens()
+
+	// When
+	key, value, err := dict.Select(3)
+	_, _, negativeErr := dict.Select(-1)
+	_, _, tooLargeErr := dict.Select(5)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 40, key)
+	assert.Equal(t, "v40", value)
+	assert.Error(t, negativeErr)
+	assert.Error(t, tooLargeErr)
+	for _, k := range []int{10, 20, 30, 40, 50} {
+		selected, _, _ := dict.Select(dict.Rank(k))
+		assert.Equal(t, k, selected)
+	}
+}
+
+// ORDERED DICT BACKEND TESTS
+
+var orderedDictBackends = map[string]Backend{
+	"linked list":  LinkedListBackend,
+	"sorted array": SortedArrayBackend,
+	"skip list":    SkipListBackend,
+}
+
+func Test_GivenEveryBackend_WhenPuttingAndDeleting_ThenSameBehaviour(t *testing.T) {
+	for name, backend := range orderedDictBackends {
+		t.Run(name, func(t *testing.T) {
+			// Given
+			dict := NewOrderedDict[int, string](WithBackend(backend))
+
+			// When
+			for i := 0; i < 200; i++ {
+				key := (i * 37) % 200
+				dict.Put(key, fmt.Sprintf("v%d", key))
+			}
+			dict.Put(100, "updated")
+			for key := 0; key < 200; key += 2 {
+				assert.NoError(t, dict.Delete(key))
+			}
+
+			// Then
+			assert.Error(t, dict.Delete(0))
+			var keys []int
+			for k, v := range dict.All() {
+				keys = append(keys, k)
+				assert.Equal(t, fmt.Sprintf("v%d", k), v)
+			}
+			assert.Len(t, keys, 100)
+			assert.True(t, slices.IsSorted(keys))
+			assert.False(t, dict.IsKey(100))
+			assert.True(t, dict.IsKey(101))
+		})
+	}
+}
+
+func Test_GivenEveryBackend_WhenRunningOrderedQueries_ThenSameAnswers(t *testing.T) {
+	for name, backend := range orderedDictBackends {
+		t.Run(name, func(t *testing.T) {
+			// Given
+			dict := NewOrderedDict[int, string](WithBackend(backend))
+			for _, key := range []int{30, 10, 50, 20, 40} {
+				dict.Put(key, fmt.Sprintf("v%d", key))
+			}
+
+			// When
+			floorKey, _, _ := dict.Floor(39)
+			ceilingKey, _, _ := dict.Ceiling(31)
+			selectedKey, selectedValue, _ := dict.Select(3)
+			maxKey, _, _ := dict.Max()
+			var backward []int
+			for k := range dict.Backward() {
+				backward = append(backward, k)
+			}
+
+			// Then
+			assert.Equal(t, 30, floorKey)
+			assert.Equal(t, 40, ceilingKey)
+			assert.Equal(t, 40, selectedKey)
+			assert.Equal(t, "v40", selectedValue)
+			assert.Equal(t, 50, maxKey)
+			assert.Equal(t, 3, dict.Rank(35))
+			assert.Equal(t, []int{50, 40, 30, 20, 10}, backward)
+		})
+	}
+}
+
+// backend wrapper which counts the keys the dictionary reads through AllFrom
+type countingSet struct {
+	OrderedSet[int]
+	read int
+}
+
+func (s *countingSet) AllFrom(position int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for key := range s.OrderedSet.AllFrom(position) {
+			s.read++
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+func Test_GivenLargeDictOnEveryBackend_WhenRanging_ThenBoundsInclusiveAndOnlyRangeRead(t *testing.T) {
+	for name, backend := range orderedDictBackends {
+		t.Run(name, func(t *testing.T) {
+			// Given
+			dict := NewOrderedDict[int, int](WithBackend(backend))
+			for key := 0; key < 20000; key += 2 {
+				dict.Put(key, -key)
+			}
+			keys := &countingSet{OrderedSet: dict.keys}
+			dict.keys = keys
+			rangeKeys := func(lo, hi int) []int {
+				var result []int
+				for k, v := range dict.Range(lo, hi) {
+					assert.Equal(t, -k, v)
+					result = append(result, k)
+				}
+				return result
+			}
+
+			// When
+			onKeys := rangeKeys(10000, 10010)
+			readOnKeys := keys.read
+			betweenKeys := rangeKeys(9999, 10011)
+			belowAll := rangeKeys(-10, 2)
+			aboveAll := rangeKeys(19998, 30000)
+			empty := rangeKeys(10001, 10001)
+
+			// Then
+			assert.Equal(t, []int{10000, 10002, 10004, 10006, 10008, 10010}, onKeys)
+			assert.Equal(t, 7, readOnKeys)
+			assert.Equal(t, []int{10000, 10002, 10004, 10006, 10008, 10010}, betweenKeys)
+			assert.Equal(t, []int{0, 2}, belowAll)
+			assert.Equal(t, []int{19998}, aboveAll)
+			assert.Empty(t, empty)
+			assert.Equal(t, 7+7+3+1+1, keys.read)
+		})
+	}
+}
+
+func Test_GivenSortedArray_WhenFindingPosition_ThenBinarySearchInsertPosition(t *testing.T) {
+	// Given
+	keys := &SortedArray[int]{}
+	for _, key := range []int{5, 1, 3} {
+		keys.Add(key)
+	}
+
+	// When/Then
+	position, found := keys.FindPosition(3)
+	assert.Equal(t, 1, position)
+	assert.True(t, found)
+	position, found = keys.FindPosition(4)
+	assert.Equal(t, 2, position)
+	assert.False(t, found)
+	position, found = keys.FindPosition(9)
+	assert.Equal(t, 3, position)
+	assert.False(t, found)
+}
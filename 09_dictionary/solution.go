@@ -1,16 +1,28 @@
-package main
+package dictionary
 
 import (
-	"os"
-	"strconv"
 	"errors"
+	"iter"
+
+	"github.com/vernon-gant/algos1-go/08_hash_table"
+)
+
+const (
+	MaxLoad    = 0.75
+	GrowFactor = 2
 )
 
+var errModifiedDuringIteration = errors.New("collection was modified during iteration")
+
 type NativeDictionary[T any] struct {
 	size     int
 	slots    []string
 	values   []T
 	occupied []bool
+	count    int
+	seed     uint64
+	seeded   bool
+	version  int
 }
 
 func Init[T any](sz int) NativeDictionary[T] {
@@ -21,74 +33,179 @@ func Init[T any](sz int) NativeDictionary[T] {
 	return nd
 }
 
+// every seed is a valid one, 0 included, see HashTable's InitSeeded
+func InitSeeded[T any](sz int, seed uint64) NativeDictionary[T] {
+	nd := Init[T](sz)
+	nd.seed = seed
+	nd.seeded = true
+	return nd
+}
+
+// the same polynomial hash as in HashTable, computed with Horner so it does not overflow on long keys
 func (nd *NativeDictionary[T]) HashFun(value string) int {
-	result := 0
-	for i := 0; i < len(value); i++ {
-		pow := 1
-		for j := 0; j < len(value)-i; j++ {
-			pow *= 26
-		}
-		result += (int(value[i]) * pow) % len(nd.slots)
+	if nd.seeded {
+		return hashtable.SeededPolyHash(value, nd.seed, len(nd.slots))
 	}
-	return result % len(nd.slots)
+	return hashtable.PolyHash(value, len(nd.slots))
 }
 
 func (nd *NativeDictionary[T]) IsKey(key string) bool {
-	startIdx := nd.HashFun(key)
-	idx := startIdx
+	return nd.findIndex(key) != -1
+}
+
+func (nd *NativeDictionary[T]) Get(key string) (T, error) {
+	var result T
+	idx := nd.findIndex(key)
+
+	if idx == -1 {
+		return result, errors.New("key not found")
+	}
 
-	for {
-		if !nd.occupied[idx] {
-			return false
+	return nd.values[idx], nil
+}
+
+// key value pairs in slot order, panics if a key is put or deleted before the iteration is over. Overwriting the value of an
+// existing key keeps the key set as it is, so that is allowed
+func (nd *NativeDictionary[T]) All() iter.Seq2[string, T] {
+	return func(yield func(string, T) bool) {
+		version := nd.version
+		for i := range nd.slots {
+			if !nd.occupied[i] {
+				continue
+			}
+			if !yield(nd.slots[i], nd.values[i]) {
+				return
+			}
+			if nd.version != version {
+				panic(errModifiedDuringIteration)
+			}
 		}
+	}
+}
 
-		if nd.slots[idx] == key {
-			return true
+func (nd *NativeDictionary[T]) Keys() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for key := range nd.All() {
+			if !yield(key) {
+				return
+			}
 		}
+	}
+}
+
+func (nd *NativeDictionary[T]) Len() int {
+	return nd.count
+}
+
+// the table grows before it gets more than MaxLoad full, also from no slots at all, so the error is never returned in practice
+func (nd *NativeDictionary[T]) Put(key string, value T) error {
+	if idx := nd.findIndex(key); idx != -1 {
+		nd.values[idx] = value
+		return nil
+	}
+
+	// the hash multiplies by 26 in the end, so with an even size every key would start at an even slot - keep the size odd
+	if float64(nd.count+1) > MaxLoad*float64(len(nd.slots)) {
+		nd.grow(len(nd.slots)*GrowFactor + 1)
+	}
+
+	startIdx := nd.HashFun(key)
+	idx := startIdx
 
+	for nd.occupied[idx] {
 		idx = (idx + 1) % len(nd.slots)
 
 		if idx == startIdx {
-			return false
+			return errors.New("dictionary is full")
 		}
 	}
+
+	nd.slots[idx] = key
+	nd.values[idx] = value
+	nd.occupied[idx] = true
+	nd.count++
+	nd.version++
+	return nil
 }
 
-func (nd *NativeDictionary[T]) Get(key string) (T, error) {
-	startIdx := nd.HashFun(key)
-	idx := startIdx
-	var result T
+/*
+* For linear probing we do not need tombstones - after removing the key we walk the cluster behind it and shift back every key
+* whose home slot is not between the hole and the key itself (cyclically). Such key was pushed over the hole during its Put, so
+* without the shift the search would stop at the hole and never reach it. After the shift the hole moves to where the key was
+* and we continue until the first free slot. This way every cluster stays exactly as if the deleted key was never put.
+*/
 
-	for {
-		if !nd.occupied[idx] {
-			return result, errors.New("key not found")
-		}
+func (nd *NativeDictionary[T]) Delete(key string) error {
+	hole := nd.findIndex(key)
 
-		if nd.slots[idx] == key {
-			return nd.values[idx], nil
-		}
+	if hole == -1 {
+		return errors.New("key not found")
+	}
 
-		idx = (idx + 1) % len(nd.slots)
+	var zero T
 
-		if idx == startIdx {
-			return result, errors.New("key not found")
+	for idx := (hole + 1) % len(nd.slots); nd.occupied[idx]; idx = (idx + 1) % len(nd.slots) {
+		home := nd.HashFun(nd.slots[idx])
+
+		if isCyclicallyBetween(hole, home, idx) {
+			continue
 		}
+
+		nd.slots[hole] = nd.slots[idx]
+		nd.values[hole] = nd.values[idx]
+		hole = idx
 	}
+
+	nd.slots[hole] = ""
+	nd.values[hole] = zero
+	nd.occupied[hole] = false
+	nd.count--
+	nd.version++
+	return nil
 }
 
-func (nd *NativeDictionary[T]) Put(key string, value T) {
+func (nd *NativeDictionary[T]) findIndex(key string) int {
+	if len(nd.slots) == 0 {
+		return -1
+	}
+
 	startIdx := nd.HashFun(key)
 	idx := startIdx
 
-	for nd.occupied[idx] && nd.slots[idx] != key {
+	for nd.occupied[idx] {
+		if nd.slots[idx] == key {
+			return idx
+		}
+
 		idx = (idx + 1) % len(nd.slots)
 
 		if idx == startIdx {
-			return
+			return -1
 		}
 	}
 
-	nd.slots[idx] = key
-	nd.values[idx] = value
-	nd.occupied[idx] = true
+	return -1
+}
+
+func (nd *NativeDictionary[T]) grow(newSize int) {
+	oldSlots, oldValues, oldOccupied := nd.slots, nd.values, nd.occupied
+	nd.size = newSize
+	nd.slots = make([]string, newSize)
+	nd.values = make([]T, newSize)
+	nd.occupied = make([]bool, newSize)
+	nd.count = 0
+
+	for i := range oldSlots {
+		if oldOccupied[i] {
+			nd.Put(oldSlots[i], oldValues[i])
+		}
+	}
+}
+
+// whether home lies in the cyclic range (hole, idx]
+func isCyclicallyBetween(hole, home, idx int) bool {
+	if hole <= idx {
+		return hole < home && home <= idx
+	}
+	return hole < home || home <= idx
 }